@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/vinted/sonic-exporter/internal/collector"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const readyRedisTimeout = 2 * time.Second
+
+// readyDatabases are the databases collectors read. CHASSIS_APP_DB and
+// CHASSIS_STATE_DB live on the redis_chassis instance, which only runs on
+// chassis platforms.
+var readyDatabases = []string{"APPL_DB", "ASIC_DB", "COUNTERS_DB", "CONFIG_DB", "STATE_DB"}
+
+type readyStatus struct {
+	Status     string            `json:"status"`
+	Redis      redisStatus       `json:"redis"`
+	Collectors []collectorStatus `json:"collectors"`
+}
+
+type redisStatus struct {
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+}
+
+type collectorStatus struct {
+	Name                   string     `json:"name"`
+	Ready                  bool       `json:"ready"`
+	Success                bool       `json:"success"`
+	LastRefresh            *time.Time `json:"last_refresh,omitempty"`
+	RefreshIntervalSeconds float64    `json:"refresh_interval_seconds,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, logger *slog.Logger, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("Error writing response", "error", err)
+	}
+}
+
+// healthyHandler reports that the process is alive.
+func healthyHandler(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, logger, http.StatusOK, map[string]string{"status": "healthy"})
+	}
+}
+
+// readyHandler reports ready when the Redis instances serving readyDatabases
+// are reachable and every enabled collector refreshed successfully within
+// maxIntervals refresh intervals.
+// It only reads cached collector state and never triggers a scrape.
+func readyHandler(collectorSet *collector.CollectorSet, redisClient redis.Client, maxIntervals int, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyRedisTimeout)
+		defer cancel()
+
+		status := readyStatus{Status: "ready", Redis: redisStatus{Reachable: true}}
+		if err := redisClient.Ping(ctx, readyDatabases...); err != nil {
+			status.Status = "not ready"
+			status.Redis = redisStatus{Reachable: false, Error: err.Error()}
+		}
+
+		now := time.Now()
+		for _, sonicCollector := range collectorSet.Collectors() {
+			health := sonicCollector.Health()
+			collectorStatus := collectorStatus{
+				Name:                   sonicCollector.Name(),
+				Ready:                  health.Ready(now, maxIntervals),
+				Success:                health.Success,
+				RefreshIntervalSeconds: health.RefreshInterval.Seconds(),
+			}
+			if !health.LastRefresh.IsZero() {
+				collectorStatus.LastRefresh = &health.LastRefresh
+			}
+			if !collectorStatus.Ready {
+				status.Status = "not ready"
+			}
+
+			status.Collectors = append(status.Collectors, collectorStatus)
+		}
+
+		statusCode := http.StatusOK
+		if status.Status != "ready" {
+			statusCode = http.StatusServiceUnavailable
+		}
+		writeJSON(w, logger, statusCode, status)
+	}
+}
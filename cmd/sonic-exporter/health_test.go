@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/promslog"
+	"github.com/vinted/sonic-exporter/internal/collector"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+func getReady(t *testing.T, collectorSet *collector.CollectorSet, redisClient redis.Client, maxIntervals int) (int, readyStatus) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	readyHandler(collectorSet, redisClient, maxIntervals, promslog.New(&promslog.Config{})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/-/ready", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	var status readyStatus
+	if err := json.NewDecoder(recorder.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode ready response: %v", err)
+	}
+
+	return recorder.Code, status
+}
+
+func findCollectorStatus(t *testing.T, status readyStatus, name string) collectorStatus {
+	t.Helper()
+
+	for _, collectorStatus := range status.Collectors {
+		if collectorStatus.Name == name {
+			return collectorStatus
+		}
+	}
+
+	t.Fatalf("collector %q missing from ready response %+v", name, status)
+	return collectorStatus{}
+}
+
+func TestHealthyHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	healthyHandler(promslog.New(&promslog.Config{})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/-/healthy", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if body := recorder.Body.String(); body != "{\"status\":\"healthy\"}\n" {
+		t.Errorf("body = %q, want healthy status", body)
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan", "redis_pool")
+
+	code, status := getReady(t, collectorSet, testRedisClient, 3)
+	if code != http.StatusOK || status.Status != "ready" {
+		t.Fatalf("ready = %d %q, want %d ready", code, status.Status, http.StatusOK)
+	}
+	if !status.Redis.Reachable || status.Redis.Error != "" {
+		t.Errorf("redis = %+v, want reachable", status.Redis)
+	}
+
+	vlan := findCollectorStatus(t, status, "vlan")
+	if !vlan.Ready || !vlan.Success || vlan.LastRefresh == nil || vlan.RefreshIntervalSeconds != 30 {
+		t.Errorf("vlan = %+v, want ready after a successful refresh every 30 seconds", vlan)
+	}
+
+	// Collectors refreshing on scrape have no refresh interval
+	redisPool := findCollectorStatus(t, status, "redis_pool")
+	if !redisPool.Ready || redisPool.RefreshIntervalSeconds != 0 {
+		t.Errorf("redis_pool = %+v, want ready without refresh interval", redisPool)
+	}
+}
+
+func TestReadyHandlerRedisUnreachable(t *testing.T) {
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan")
+	server, redisClient := newTestRedis(t)
+	server.Close()
+
+	code, status := getReady(t, collectorSet, redisClient, 3)
+	if code != http.StatusServiceUnavailable || status.Status != "not ready" {
+		t.Errorf("ready = %d %q, want %d not ready", code, status.Status, http.StatusServiceUnavailable)
+	}
+	if status.Redis.Reachable || status.Redis.Error == "" {
+		t.Errorf("redis = %+v, want unreachable with an error", status.Redis)
+	}
+	if vlan := findCollectorStatus(t, status, "vlan"); !vlan.Ready {
+		t.Errorf("vlan = %+v, want ready", vlan)
+	}
+}
+
+func TestReadyHandlerCollectorWithoutRefresh(t *testing.T) {
+	server, redisClient := newTestRedis(t)
+	server.SetError("LOADING")
+	collectorSet := newTestCollectorSet(t, redisClient, "vlan")
+
+	code, status := getReady(t, collectorSet, testRedisClient, 3)
+	if code != http.StatusServiceUnavailable || status.Status != "not ready" {
+		t.Errorf("ready = %d %q, want %d not ready", code, status.Status, http.StatusServiceUnavailable)
+	}
+
+	vlan := findCollectorStatus(t, status, "vlan")
+	if vlan.Ready || vlan.Success || vlan.LastRefresh != nil {
+		t.Errorf("vlan = %+v, want not ready without a successful refresh", vlan)
+	}
+}
+
+func TestReadyHandlerRefreshIntervals(t *testing.T) {
+	t.Setenv("VLAN_REFRESH_INTERVAL", "50ms")
+	server, redisClient := newTestRedis(t)
+	collectorSet := newTestCollectorSet(t, redisClient, "vlan")
+
+	// Refreshes fail from now on, the last success only gets older
+	server.SetError("LOADING")
+	time.Sleep(200 * time.Millisecond)
+
+	code, status := getReady(t, collectorSet, testRedisClient, 2)
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("ready with 2 intervals = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if vlan := findCollectorStatus(t, status, "vlan"); vlan.Ready || vlan.LastRefresh == nil {
+		t.Errorf("vlan = %+v, want not ready with an old refresh", vlan)
+	}
+
+	code, status = getReady(t, collectorSet, testRedisClient, 1000)
+	if code != http.StatusOK {
+		t.Errorf("ready with 1000 intervals = %d, want %d", code, http.StatusOK)
+	}
+	if vlan := findCollectorStatus(t, status, "vlan"); !vlan.Ready || vlan.Success {
+		t.Errorf("vlan = %+v, want ready within 1000 intervals despite failed refreshes", vlan)
+	}
+}
+
+func TestReadyHandlerWithoutChassisInstance(t *testing.T) {
+	server, _ := newTestRedis(t)
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan")
+
+	// Stock database_config.json of a non-chassis switch, redis_chassis is not running
+	configFile := filepath.Join(t.TempDir(), "database_config.json")
+	config := fmt.Sprintf(`{
+		"INSTANCES": {
+			"redis": {"hostname": %q, "port": %s},
+			"redis_chassis": {"hostname": "127.0.0.1", "port": 1}
+		},
+		"DATABASES": {
+			"APPL_DB": {"id": 0, "instance": "redis"},
+			"ASIC_DB": {"id": 1, "instance": "redis"},
+			"COUNTERS_DB": {"id": 2, "instance": "redis"},
+			"CONFIG_DB": {"id": 4, "instance": "redis"},
+			"STATE_DB": {"id": 6, "instance": "redis"},
+			"CHASSIS_APP_DB": {"id": 12, "instance": "redis_chassis"},
+			"CHASSIS_STATE_DB": {"id": 13, "instance": "redis_chassis"}
+		}
+	}`, server.Host(), server.Port())
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", configFile)
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+	redisClient, err := redis.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create redis client: %v", err)
+	}
+	t.Cleanup(redisClient.Close)
+
+	code, status := getReady(t, collectorSet, redisClient, 3)
+	if code != http.StatusOK || status.Status != "ready" {
+		t.Errorf("ready = %d %q, want %d ready", code, status.Status, http.StatusOK)
+	}
+	if !status.Redis.Reachable {
+		t.Errorf("redis = %+v, want reachable", status.Redis)
+	}
+}
@@ -7,126 +7,95 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/common/promslog/flag"
 	"github.com/prometheus/exporter-toolkit/web"
 	webflag "github.com/prometheus/exporter-toolkit/web/kingpinflag"
-	nodecollector "github.com/prometheus/node_exporter/collector"
 	"github.com/vinted/sonic-exporter/internal/collector"
+	"github.com/vinted/sonic-exporter/internal/config"
+	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
 func main() {
-	// setup node exporter collectors through global kingpin flags
-	_, err := kingpin.CommandLine.Parse([]string{
-		"--collector.loadavg",
-		"--collector.cpu",
-		"--collector.diskstats",
-		"--collector.filesystem",
-		"--collector.meminfo",
-		"--collector.time",
-		"--collector.stat",
-	})
-	if err != nil {
-		slog.Error("failed to parse node exporter collector defaults", "error", err)
-		os.Exit(1)
-	}
-
 	// New kingpin instance to prevent imported code from adding flags (node exporter)
 	kp := kingpin.New("sonic-exporter", "Prometheus exporter for SONiC network switches")
 
 	var (
-		webConfig   = webflag.AddFlags(kp, ":9101")
-		metricsPath = kp.Flag("web.telemetry-path", "Path under which to expose metrics.").Default("/metrics").String()
+		webConfig      = webflag.AddFlags(kp, ":9101")
+		metricsPath    = kp.Flag("web.telemetry-path", "Path under which to expose metrics.").Default("/metrics").String()
+		configFile     = kp.Flag("config.file", "Path to YAML configuration file. Environment variables override its values.").Default("").String()
+		readyIntervals = kp.Flag("web.ready-refresh-intervals", "Report not ready when a collector has no successful refresh within this many refresh intervals.").Default("3").Int()
+		reloadToken    = kp.Flag("web.reload-token-file", "Path to a file with the bearer token required by POST /-/reload. The endpoint is disabled without it.").Default("").String()
 	)
+	collectorFlags := collector.AddCollectorFlags(kp)
 
 	promslogConfig := &promslog.Config{}
 	flag.AddFlags(kp, promslogConfig)
 	kp.HelpFlag.Short('h')
 	kp.UsageWriter(os.Stdout)
-	if _, err = kp.Parse(os.Args[1:]); err != nil {
+	if _, err := kp.Parse(os.Args[1:]); err != nil {
 		slog.Error("failed to parse command line arguments", "error", err)
 		os.Exit(1)
 	}
 
 	logger := promslog.New(promslogConfig)
-	metricFilter := collector.NewMetricFilter(logger)
 
-	// SONiC collectors
-	interfaceCollector := collector.NewInterfaceCollector(logger, metricFilter)
-	hwCollector := collector.NewHwCollector(logger, metricFilter)
-	crmCollector := collector.NewCrmCollector(logger, metricFilter)
-	queueCollector := collector.NewQueueCollector(logger, metricFilter)
-	lldpCollector := collector.NewLldpCollector(logger, metricFilter)
-	vlanCollector := collector.NewVlanCollector(logger, metricFilter)
-	lagCollector := collector.NewLagCollector(logger, metricFilter)
-	fdbCollector := collector.NewFdbCollector(logger, metricFilter)
-	routingCollector := collector.NewRoutingCollector(logger, metricFilter)
-	switchCollector := collector.NewSwitchCollector(logger, metricFilter)
-	thermalCollector := collector.NewThermalCollector(logger, metricFilter)
-	transceiverCollector := collector.NewTransceiverCollector(logger, metricFilter)
-	platformHealthCollector := collector.NewPlatformHealthCollector(logger, metricFilter)
-	systemCollector := collector.NewSystemCollector(logger, metricFilter)
-	dockerCollector := collector.NewDockerCollector(logger, metricFilter)
-	frrCollector := collector.NewFrrCollector(logger)
-	prometheus.MustRegister(interfaceCollector)
-	prometheus.MustRegister(hwCollector)
-	prometheus.MustRegister(crmCollector)
-	prometheus.MustRegister(queueCollector)
-	if lldpCollector.IsEnabled() {
-		prometheus.MustRegister(lldpCollector)
-	}
-	if vlanCollector.IsEnabled() {
-		prometheus.MustRegister(vlanCollector)
-	}
-	if lagCollector.IsEnabled() {
-		prometheus.MustRegister(lagCollector)
-	}
-	if fdbCollector.IsEnabled() {
-		prometheus.MustRegister(fdbCollector)
-	}
-	if routingCollector.IsEnabled() {
-		prometheus.MustRegister(routingCollector)
-	}
-	if switchCollector.IsEnabled() {
-		prometheus.MustRegister(switchCollector)
-	}
-	if thermalCollector.IsEnabled() {
-		prometheus.MustRegister(thermalCollector)
-	}
-	if transceiverCollector.IsEnabled() {
-		prometheus.MustRegister(transceiverCollector)
+	if err := config.Load(*configFile); err != nil {
+		logger.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
 	}
-	if platformHealthCollector.IsEnabled() {
-		prometheus.MustRegister(platformHealthCollector)
+
+	metricFilter, err := collector.NewMetricFilter(logger)
+	if err != nil {
+		logger.Error("Failed to load metric filter", "error", err)
+		os.Exit(1)
 	}
-	if systemCollector.IsEnabled() {
-		prometheus.MustRegister(systemCollector)
+
+	// Single pooled redis client shared by all SONiC collectors
+	redisClient, err := redis.NewClient()
+	if err != nil {
+		logger.Error("Failed to create redis client", "error", err)
+		os.Exit(1)
 	}
-	if dockerCollector.IsEnabled() {
-		prometheus.MustRegister(dockerCollector)
+	defer redisClient.Close()
+
+	if *readyIntervals <= 0 {
+		logger.Error("Invalid --web.ready-refresh-intervals, must be greater than zero", "value", *readyIntervals)
+		os.Exit(1)
 	}
-	if frrCollector.IsEnabled() {
-		prometheus.MustRegister(frrCollector)
+
+	token, err := readReloadToken(*reloadToken)
+	if err != nil {
+		logger.Error("Failed to configure reload endpoint", "error", err)
+		os.Exit(1)
 	}
 
-	// Node exporter collectors
-	nodeCollector, err := nodecollector.NewNodeCollector(logger,
-		"loadavg",
-		"cpu",
-		"diskstats",
-		"filesystem",
-		"meminfo",
-		"time",
-		"stat",
-	)
+	// Node exporter collectors are read once, changing them needs a restart.
+	// They are created before the FRR collector parses its flags, as every
+	// kingpin.CommandLine parse resets the flags it is not given.
+	upstreamNodeCollector, err := newNodeCollector(logger, nodeCollectorNames())
 	if err != nil {
 		logger.Error("Failed to create node collector", "error", err)
 		os.Exit(1)
 	}
+
+	// SONiC collectors, rebuilt on SIGHUP and POST /-/reload
+	collectorSet := collector.NewCollectorSet(collector.CollectorOptions{
+		Logger:       logger,
+		MetricFilter: metricFilter,
+		RedisClient:  redisClient,
+	}, collectorFlags, *configFile)
+	prometheus.MustRegister(collectorSet)
+	reloadOnSIGHUP(collectorSet, logger)
+
+	// Node exporter collectors, filtered like the SONiC collectors
+	nodeCollector := collector.NewFilteredCollector(upstreamNodeCollector, collectorSet.MetricFilter)
 	prometheus.MustRegister(nodeCollector)
 
-	http.Handle(*metricsPath, promhttp.Handler())
+	http.Handle(*metricsPath, metricsHandler(collectorSet, nodeCollector, logger))
+	http.Handle("/-/reload", reloadHandler(collectorSet, token, logger))
+	http.Handle("/-/healthy", healthyHandler(logger))
+	http.Handle("/-/ready", readyHandler(collectorSet, redisClient, *readyIntervals, logger))
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, err := w.Write([]byte(`<html>
              <head><title>Sonic Exporter</title></head>
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/common/promslog"
+	"github.com/vinted/sonic-exporter/internal/collector"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+var (
+	testRedisServer *miniredis.Miniredis
+	testRedisClient redis.Client
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testRedisServer, err = miniredis.Run()
+	if err != nil {
+		slog.Error("failed to start redis", "error", err)
+		os.Exit(1)
+	}
+
+	os.Setenv("REDIS_ADDRESS", testRedisServer.Addr())
+	testRedisClient, err = redis.NewClient()
+	if err != nil {
+		slog.Error("failed to create redis client", "error", err)
+		os.Exit(1)
+	}
+
+	exitCode := m.Run()
+
+	testRedisClient.Close()
+	testRedisServer.Close()
+	os.Unsetenv("REDIS_ADDRESS")
+
+	os.Exit(exitCode)
+}
+
+// newTestCollectorSet builds a collector set reading redisClient and running
+// only the named collectors, the others are turned off by their
+// --no-collector.<name> flag.
+func newTestCollectorSet(t *testing.T, redisClient redis.Client, names ...string) *collector.CollectorSet {
+	t.Helper()
+
+	app := kingpin.New("test", "")
+	flags := collector.AddCollectorFlags(app)
+	args := []string{}
+	for _, flag := range app.Model().Flags {
+		name, ok := strings.CutPrefix(flag.Name, "collector.")
+		if !ok {
+			continue
+		}
+		if slices.Contains(names, name) {
+			args = append(args, "--collector."+name)
+		} else {
+			args = append(args, "--no-collector."+name)
+		}
+	}
+	if _, err := app.Parse(args); err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+
+	logger := promslog.New(&promslog.Config{})
+	metricFilter, err := collector.NewMetricFilter(logger)
+	if err != nil {
+		t.Fatalf("NewMetricFilter() error = %v", err)
+	}
+
+	set := collector.NewCollectorSet(collector.CollectorOptions{
+		Logger:       logger,
+		MetricFilter: metricFilter,
+		RedisClient:  redisClient,
+	}, flags, "")
+	t.Cleanup(func() {
+		for _, sonicCollector := range set.Collectors() {
+			sonicCollector.Stop()
+		}
+	})
+
+	return set
+}
+
+// newTestRedis starts a separate Redis server for tests that break it.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, redis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDRESS", server.Addr())
+	redisClient, err := redis.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create redis client: %v", err)
+	}
+	t.Cleanup(redisClient.Close)
+
+	return server, redisClient
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vinted/sonic-exporter/internal/collector"
+)
+
+// nodeCollectorName selects the node_exporter subset in collect[] filters.
+const nodeCollectorName = "node"
+
+// metricsHandler serves every registered metric, or only the collectors named
+// in collect[] URL parameters, e.g. /metrics?collect[]=interface&collect[]=queue.
+// Filtered requests are served from a registry built for the request.
+func metricsHandler(collectorSet *collector.CollectorSet, nodeCollector prometheus.Collector, logger *slog.Logger) http.HandlerFunc {
+	unfiltered := promhttp.Handler()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		names := r.URL.Query()["collect[]"]
+		if len(names) == 0 {
+			unfiltered.ServeHTTP(w, r)
+			return
+		}
+
+		registry, err := filteredRegistry(collectorSet, nodeCollector, names)
+		if err != nil {
+			logger.Warn("Invalid collect[] filter", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+			ErrorLog:      slog.NewLogLogger(logger.Handler(), slog.LevelError),
+			ErrorHandling: promhttp.ContinueOnError,
+		}).ServeHTTP(w, r)
+	}
+}
+
+func filteredRegistry(collectorSet *collector.CollectorSet, nodeCollector prometheus.Collector, names []string) (*prometheus.Registry, error) {
+	collectors := map[string]prometheus.Collector{nodeCollectorName: nodeCollector}
+	for _, sonicCollector := range collectorSet.Collectors() {
+		collectors[sonicCollector.Name()] = sonicCollector
+	}
+
+	registry := prometheus.NewRegistry()
+	registered := map[string]bool{}
+	for _, name := range names {
+		if registered[name] {
+			continue
+		}
+
+		selected, ok := collectors[name]
+		if !ok {
+			return nil, fmt.Errorf("collector %q is unknown or disabled", name)
+		}
+
+		if err := registry.Register(selected); err != nil {
+			return nil, fmt.Errorf("failed to register collector %q: %w", name, err)
+		}
+		registered[name] = true
+	}
+
+	return registry, nil
+}
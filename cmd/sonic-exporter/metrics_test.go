@@ -0,0 +1,109 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/promslog"
+)
+
+func TestMetricsHandlerCollectFilter(t *testing.T) {
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan", "redis_pool", "queue", "priority_group")
+	nodeCollector := prometheus.NewGauge(prometheus.GaugeOpts{Name: "node_test_gauge", Help: "Stands in for the node_exporter subset"})
+	handler := metricsHandler(collectorSet, nodeCollector, promslog.New(&promslog.Config{}))
+
+	// The set exports its own metrics unless collect[] is used
+	if count := testutil.CollectAndCount(collectorSet, "sonic_exporter_config_last_reload_success"); count != 1 {
+		t.Fatalf("sonic_exporter_config_last_reload_success count = %d, want 1", count)
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantPrefix  []string
+		wantMissing []string
+	}{
+		{
+			name:        "node",
+			query:       "collect[]=node",
+			wantStatus:  http.StatusOK,
+			wantPrefix:  []string{"node_test_gauge"},
+			wantMissing: []string{"sonic_", "go_", "process_"},
+		},
+		{
+			name:        "one collector",
+			query:       "collect[]=vlan",
+			wantStatus:  http.StatusOK,
+			wantPrefix:  []string{"sonic_vlan_"},
+			wantMissing: []string{"node_", "sonic_redis_pool_", "sonic_exporter_", "go_", "process_"},
+		},
+		{
+			name:        "queue without priority groups",
+			query:       "collect[]=queue",
+			wantStatus:  http.StatusOK,
+			wantPrefix:  []string{"sonic_queue_"},
+			wantMissing: []string{"sonic_priority_group_"},
+		},
+		{
+			name:        "priority groups without queues",
+			query:       "collect[]=priority_group",
+			wantStatus:  http.StatusOK,
+			wantPrefix:  []string{"sonic_priority_group_"},
+			wantMissing: []string{"sonic_queue_"},
+		},
+		{
+			name:        "repeated collectors",
+			query:       "collect[]=vlan&collect[]=node&collect[]=vlan",
+			wantStatus:  http.StatusOK,
+			wantPrefix:  []string{"sonic_vlan_", "node_test_gauge"},
+			wantMissing: []string{"sonic_redis_pool_", "sonic_exporter_"},
+		},
+		{
+			name:       "unknown collector",
+			query:      "collect[]=vlan&collect[]=nope",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "disabled collector",
+			query:      "collect[]=fdb",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics?"+tt.query, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if !strings.Contains(recorder.Body.String(), "is unknown or disabled") {
+					t.Errorf("body = %q, want unknown or disabled collector error", recorder.Body.String())
+				}
+				return
+			}
+
+			lines := strings.Split(recorder.Body.String(), "\n")
+			for _, prefix := range tt.wantPrefix {
+				if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, prefix) }) {
+					t.Errorf("no %s series in response:\n%s", prefix, recorder.Body.String())
+				}
+			}
+			for _, line := range lines {
+				for _, prefix := range tt.wantMissing {
+					if strings.HasPrefix(line, prefix) {
+						t.Errorf("unexpected series %q", line)
+					}
+				}
+			}
+		})
+	}
+}
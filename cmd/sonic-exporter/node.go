@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	nodecollector "github.com/prometheus/node_exporter/collector"
+)
+
+// defaultNodeCollectors is the node_exporter subset used when
+// NODE_COLLECTORS is not set.
+const defaultNodeCollectors = "loadavg,cpu,diskstats,filesystem,meminfo,time,stat"
+
+// nodeCollectorNames returns the node_exporter collectors listed in the
+// comma separated NODE_COLLECTORS env var.
+func nodeCollectorNames() []string {
+	value := strings.TrimSpace(os.Getenv("NODE_COLLECTORS"))
+	if value == "" {
+		value = defaultNodeCollectors
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// newNodeCollector enables the named node_exporter collectors through the
+// global kingpin flags node_exporter registers, then creates a collector
+// restricted to them.
+func newNodeCollector(logger *slog.Logger, names []string) (*nodecollector.NodeCollector, error) {
+	args := make([]string, 0, len(names))
+	for _, name := range names {
+		args = append(args, "--collector."+name)
+	}
+
+	if _, err := kingpin.CommandLine.Parse(args); err != nil {
+		return nil, fmt.Errorf("failed to enable node_exporter collectors %v: %w", names, err)
+	}
+
+	return nodecollector.NewNodeCollector(logger, names...)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/vinted/sonic-exporter/internal/collector"
+)
+
+// readReloadToken returns the bearer token required by /-/reload. An empty
+// path disables the endpoint.
+func readReloadToken(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reload token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.New("reload token file is empty")
+	}
+
+	return token, nil
+}
+
+// reloadOnSIGHUP reloads the collectors whenever the process receives SIGHUP.
+func reloadOnSIGHUP(collectorSet *collector.CollectorSet, logger *slog.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		for range hup {
+			if err := collectorSet.Reload(); err != nil {
+				logger.Error("Failed to reload on SIGHUP", "error", err)
+			}
+		}
+	}()
+}
+
+// reloadHandler reloads the collectors on an authenticated POST request.
+func reloadHandler(collectorSet *collector.CollectorSet, token string, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, "reload endpoint is disabled, set --web.reload-token-file", http.StatusForbidden)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if err := collectorSet.Reload(); err != nil {
+			logger.Error("Failed to reload on request", "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/promslog"
+)
+
+func TestReadReloadToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte(" \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := readReloadToken(""); err != nil || token != "" {
+		t.Errorf("readReloadToken(\"\") = %q, %v, want disabled", token, err)
+	}
+	if token, err := readReloadToken(tokenFile); err != nil || token != "secret" {
+		t.Errorf("readReloadToken() = %q, %v, want secret", token, err)
+	}
+	if _, err := readReloadToken(emptyFile); err == nil {
+		t.Error("readReloadToken() of an empty file succeeded")
+	}
+	if _, err := readReloadToken(filepath.Join(dir, "missing")); err == nil {
+		t.Error("readReloadToken() of a missing file succeeded")
+	}
+}
+
+func TestReloadHandler(t *testing.T) {
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	logger := promslog.New(&promslog.Config{})
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan")
+
+	tests := []struct {
+		name          string
+		token         string
+		method        string
+		authorization string
+		disabled      string
+		wantStatus    int
+		wantHeader    string
+		wantReloaded  bool
+	}{
+		{name: "no token file", token: "", method: http.MethodPost, authorization: "Bearer secret", wantStatus: http.StatusForbidden},
+		{name: "not POST", token: "secret", method: http.MethodGet, authorization: "Bearer secret", wantStatus: http.StatusMethodNotAllowed, wantHeader: "Allow"},
+		{name: "missing token", token: "secret", method: http.MethodPost, wantStatus: http.StatusUnauthorized, wantHeader: "WWW-Authenticate"},
+		{name: "wrong token", token: "secret", method: http.MethodPost, authorization: "Bearer guess", wantStatus: http.StatusUnauthorized, wantHeader: "WWW-Authenticate"},
+		{name: "not a bearer token", token: "secret", method: http.MethodPost, authorization: "secret", wantStatus: http.StatusUnauthorized, wantHeader: "WWW-Authenticate"},
+		{name: "invalid configuration", token: "secret", method: http.MethodPost, authorization: "Bearer secret", disabled: "sonic_vlan_[", wantStatus: http.StatusInternalServerError},
+		{name: "reloaded", token: "secret", method: http.MethodPost, authorization: "Bearer secret", disabled: "sonic_vlan_*", wantStatus: http.StatusOK, wantReloaded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every request that gets through would apply this filter
+			t.Setenv("SONIC_DISABLED_METRICS", "sonic_vlan_*")
+			if tt.disabled != "" {
+				t.Setenv("SONIC_DISABLED_METRICS", tt.disabled)
+			}
+			t.Cleanup(func() {
+				t.Setenv("SONIC_DISABLED_METRICS", "")
+				if err := collectorSet.Reload(); err != nil {
+					t.Fatalf("Reload error = %v", err)
+				}
+			})
+
+			request := httptest.NewRequest(tt.method, "/-/reload", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			reloadHandler(collectorSet, tt.token, logger).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantHeader != "" && recorder.Header().Get(tt.wantHeader) == "" {
+				t.Errorf("missing %s header", tt.wantHeader)
+			}
+			if reloaded := !collectorSet.MetricFilter().Enabled("sonic_vlan_members"); reloaded != tt.wantReloaded {
+				t.Errorf("reloaded = %v, want %v", reloaded, tt.wantReloaded)
+			}
+		})
+	}
+}
+
+func TestReloadOnSIGHUP(t *testing.T) {
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	collectorSet := newTestCollectorSet(t, testRedisClient, "vlan")
+	reloadOnSIGHUP(collectorSet, promslog.New(&promslog.Config{}))
+	// Stop reloading the set before its collectors are stopped
+	t.Cleanup(func() { signal.Reset(syscall.SIGHUP) })
+
+	t.Setenv("SONIC_DISABLED_METRICS", "sonic_vlan_*")
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("failed to send SIGHUP: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for collectorSet.MetricFilter().Enabled("sonic_vlan_members") {
+		if time.Now().After(deadline) {
+			t.Fatal("configuration not reloaded after SIGHUP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
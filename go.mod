@@ -13,6 +13,7 @@ require (
 	github.com/prometheus/node_exporter v1.11.1
 	github.com/redis/go-redis/v9 v9.20.1
 	github.com/tynany/frr_exporter v1.11.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -68,7 +69,6 @@ require (
 	golang.org/x/text v0.37.0 // indirect
 	golang.org/x/time v0.15.0 // indirect
 	google.golang.org/protobuf v1.36.11 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	howett.net/plist v1.0.1 // indirect
 	olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3 // indirect
 )
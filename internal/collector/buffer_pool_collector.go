@@ -0,0 +1,442 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const (
+	bufferPoolInfoMetricName              = "sonic_buffer_pool_info"
+	bufferPoolSizeMetricName              = "sonic_buffer_pool_size_bytes"
+	bufferPoolHeadroomSizeMetricName      = "sonic_buffer_pool_headroom_size_bytes"
+	bufferPoolOccupancyMetricName         = "sonic_buffer_pool_occupancy_bytes"
+	bufferPoolWatermarkMetricName         = "sonic_buffer_pool_watermark_bytes"
+	bufferPoolHeadroomWatermarkMetricName = "sonic_buffer_pool_headroom_watermark_bytes"
+	bufferPoolUtilizationMetricName       = "sonic_buffer_pool_utilization_ratio"
+	bufferPoolScrapeDurationMetricName    = "sonic_buffer_pool_scrape_duration_seconds"
+	bufferPoolCollectorSuccessMetricName  = "sonic_buffer_pool_collector_success"
+	bufferPoolCacheAgeMetricName          = "sonic_buffer_pool_cache_age_seconds"
+	bufferPoolEntriesSkippedMetricName    = "sonic_buffer_pool_entries_skipped"
+)
+
+const (
+	bufferPoolWatermarkField         = "SAI_BUFFER_POOL_STAT_WATERMARK_BYTES"
+	bufferPoolHeadroomWatermarkField = "SAI_BUFFER_POOL_STAT_XOFF_ROOM_WATERMARK_BYTES"
+	bufferPoolOccupancyField         = "SAI_BUFFER_POOL_STAT_CURR_OCCUPANCY_BYTES"
+	bufferPoolCountersNameMap        = "COUNTERS_BUFFER_POOL_NAME_MAP"
+	bufferPoolConfigKeyPrefix        = "BUFFER_POOL|"
+	bufferPoolApplKeyPrefix          = "BUFFER_POOL_TABLE:"
+)
+
+type bufferPoolCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+	redisScanCount  int64
+}
+
+type bufferPoolCollector struct {
+	info                   *prometheus.Desc
+	size                   *prometheus.Desc
+	headroomSize           *prometheus.Desc
+	occupancy              *prometheus.Desc
+	watermark              *prometheus.Desc
+	headroomWatermark      *prometheus.Desc
+	utilization            *prometheus.Desc
+	scrapeDuration         *prometheus.Desc
+	scrapeCollectorSuccess *prometheus.Desc
+	cacheAge               *prometheus.Desc
+	entriesSkipped         *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       bufferPoolCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+}
+
+func NewBufferPoolCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *bufferPoolCollector {
+	return newBufferPoolCollector(logger, metricFilter, redisClient, loadBufferPoolCollectorConfig(logger))
+}
+
+func newBufferPoolCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config bufferPoolCollectorConfig) *bufferPoolCollector {
+	const (
+		namespace = "sonic"
+		subsystem = "buffer_pool"
+	)
+
+	collector := &bufferPoolCollector{
+		info: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "info"),
+			"Non-numeric data about a buffer pool, value is always 1", []string{"pool", "direction", "mode", "namespace"}, nil),
+		size: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "size_bytes"),
+			"Size of a buffer pool", []string{"pool", "namespace"}, nil),
+		headroomSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "headroom_size_bytes"),
+			"Size of the shared headroom (xoff) of a buffer pool", []string{"pool", "namespace"}, nil),
+		occupancy: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "occupancy_bytes"),
+			"Current occupancy of a buffer pool", []string{"pool", "namespace"}, nil),
+		watermark: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "watermark_bytes"),
+			"Buffer pool watermark by watermark type: user, persistent, periodic", []string{"pool", "type", "namespace"}, nil),
+		headroomWatermark: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "headroom_watermark_bytes"),
+			"Buffer pool shared headroom watermark by watermark type: user, persistent, periodic", []string{"pool", "type", "namespace"}, nil),
+		utilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "utilization_ratio"),
+			"Buffer pool watermark divided by buffer pool size", []string{"pool", "type", "namespace"}, nil),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
+			"Time it took for exporter to refresh buffer pool metrics", nil, nil),
+		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
+			"Whether buffer pool collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest buffer pool cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of buffer pools skipped during latest refresh", nil, nil),
+		logger:       logger,
+		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("Buffer pool collector is disabled")
+		return collector
+	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *bufferPoolCollector) Name() string {
+	return "buffer_pool"
+}
+
+func (collector *bufferPoolCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *bufferPoolCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *bufferPoolCollector) Stop() {
+	close(collector.stop)
+}
+
+func (collector *bufferPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.info
+	ch <- collector.size
+	ch <- collector.headroomSize
+	ch <- collector.occupancy
+	ch <- collector.watermark
+	ch <- collector.headroomWatermark
+	ch <- collector.utilization
+	ch <- collector.scrapeDuration
+	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
+}
+
+func (collector *bufferPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
+
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
+
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled(bufferPoolCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
+	}
+
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(bufferPoolScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
+	}
+	if collector.metricFilter.Enabled(bufferPoolCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
+	}
+	if collector.metricFilter.Enabled(bufferPoolEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
+	}
+}
+
+func (collector *bufferPoolCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
+	}
+}
+
+func (collector *bufferPoolCollector) refreshMetrics() {
+	start := time.Now()
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "buffer pool", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
+
+// scrapeMetrics collects every pool configured in CONFIG_DB BUFFER_POOL|<pool>
+// or listed in COUNTERS_BUFFER_POOL_NAME_MAP. A pool with an unparsable value
+// is skipped.
+func (collector *bufferPoolCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
+	poolOids, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", bufferPoolCountersNameMap)
+	if err != nil {
+		return nil, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	configKeys, err := redisClient.ScanKeysFromDb(ctx, "CONFIG_DB", bufferPoolConfigKeyPrefix+"*", collector.config.redisScanCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan BUFFER_POOL keys: %w", err)
+	}
+
+	seen := map[string]struct{}{}
+	for pool := range poolOids {
+		seen[pool] = struct{}{}
+	}
+	for _, key := range configKeys {
+		seen[strings.TrimPrefix(key, bufferPoolConfigKeyPrefix)] = struct{}{}
+	}
+
+	pools := make([]string, 0, len(seen))
+	for pool := range seen {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+
+	configs, err := collector.readPoolConfigs(ctx, redisClient, pools)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stats, err := collector.readPoolStats(ctx, redisClient, pools, poolOids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	metrics := []prometheus.Metric{}
+	skippedEntries := 0
+	for i, pool := range pools {
+		poolMetrics, err := collector.collectPool(pool, configs[i], stats[pool], namespace)
+		if err != nil {
+			collector.logger.Debug("Skipping buffer pool", "pool", pool, "namespace", namespace, "error", err)
+			skippedEntries++
+			continue
+		}
+
+		metrics = append(metrics, poolMetrics...)
+	}
+
+	return metrics, skippedEntries, nil
+}
+
+// readPoolStats reads COUNTERS:<oid> and <TYPE>_WATERMARKS:<oid> of every pool
+// with an OID. Stats of a pool are keyed by "" for COUNTERS and by watermark
+// type for the watermark tables.
+func (collector *bufferPoolCollector) readPoolStats(ctx context.Context, redisClient redis.Client, pools []string, poolOids map[string]string) (map[string]map[string]map[string]string, error) {
+	var counted []string
+	for _, pool := range pools {
+		if _, ok := poolOids[pool]; ok {
+			counted = append(counted, pool)
+		}
+	}
+
+	stats := make(map[string]map[string]map[string]string, len(counted))
+	for _, pool := range counted {
+		stats[pool] = map[string]map[string]string{}
+	}
+
+	for _, statType := range append([]string{""}, bufferPoolWatermarkTypes...) {
+		keys := make([]string, 0, len(counted))
+		for _, pool := range counted {
+			if statType == "" {
+				keys = append(keys, "COUNTERS:"+poolOids[pool])
+			} else {
+				keys = append(keys, fmt.Sprintf("%s_WATERMARKS:%s", strings.ToUpper(statType), poolOids[pool]))
+			}
+		}
+
+		values, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", keys)
+		if err != nil {
+			return nil, fmt.Errorf("redis read failed: %w", err)
+		}
+
+		for i, pool := range counted {
+			stats[pool][statType] = values[i]
+		}
+	}
+
+	return stats, nil
+}
+
+// bufferPoolWatermarkTypes are the watermark tables SONiC keeps for pools.
+var bufferPoolWatermarkTypes = []string{"user", "persistent", "periodic"}
+
+// readPoolConfigs reads CONFIG_DB BUFFER_POOL|<pool> of every pool. With the
+// dynamic buffer model the size is computed by buffermgrd and only found in
+// APPL_DB BUFFER_POOL_TABLE:<pool>, so that is used when CONFIG_DB has none.
+func (collector *bufferPoolCollector) readPoolConfigs(ctx context.Context, redisClient redis.Client, pools []string) ([]map[string]string, error) {
+	keys := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		keys = append(keys, bufferPoolConfigKeyPrefix+pool)
+	}
+
+	configs, err := redisClient.HgetAllManyFromDb(ctx, "CONFIG_DB", keys)
+	if err != nil {
+		return nil, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	applKeys := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		applKeys = append(applKeys, bufferPoolApplKeyPrefix+pool)
+	}
+
+	applConfigs, err := redisClient.HgetAllManyFromDb(ctx, "APPL_DB", applKeys)
+	if err != nil {
+		return nil, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	for i := range configs {
+		if configs[i] == nil {
+			configs[i] = map[string]string{}
+		}
+		for _, field := range []string{"size", "xoff"} {
+			if _, ok := configs[i][field]; ok {
+				continue
+			}
+			if value, ok := applConfigs[i][field]; ok {
+				configs[i][field] = value
+			}
+		}
+	}
+
+	return configs, nil
+}
+
+// collectPool builds the metrics of one pool. stats holds COUNTERS:<oid> under
+// "" and <TYPE>_WATERMARKS:<oid> under the watermark type. Values a pool does
+// not have are not exported.
+func (collector *bufferPoolCollector) collectPool(pool string, config map[string]string, stats map[string]map[string]string, namespace string) ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
+
+	if collector.metricFilter.SeriesEnabled(bufferPoolInfoMetricName, "pool", pool, "direction", config["type"], "mode", config["mode"], "namespace", namespace) {
+		metrics = append(metrics, prometheus.MustNewConstMetric(
+			collector.info, prometheus.GaugeValue, 1, pool, config["type"], config["mode"], namespace,
+		))
+	}
+
+	size := 0.0
+	if value, ok := config["size"]; ok {
+		parsed, err := parseFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("size parse failed: %w", err)
+		}
+		size = parsed
+
+		if collector.metricFilter.SeriesEnabled(bufferPoolSizeMetricName, "pool", pool, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.size, prometheus.GaugeValue, size, pool, namespace))
+		}
+	}
+
+	if value, ok := config["xoff"]; ok {
+		headroomSize, err := parseFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("xoff parse failed: %w", err)
+		}
+
+		if collector.metricFilter.SeriesEnabled(bufferPoolHeadroomSizeMetricName, "pool", pool, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.headroomSize, prometheus.GaugeValue, headroomSize, pool, namespace))
+		}
+	}
+
+	if value, ok := stats[""][bufferPoolOccupancyField]; ok {
+		occupancy, err := parseFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("occupancy parse failed: %w", err)
+		}
+
+		if collector.metricFilter.SeriesEnabled(bufferPoolOccupancyMetricName, "pool", pool, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.occupancy, prometheus.GaugeValue, occupancy, pool, namespace))
+		}
+	}
+
+	for _, watermarkType := range bufferPoolWatermarkTypes {
+		if value, ok := stats[watermarkType][bufferPoolWatermarkField]; ok {
+			watermark, err := parseFloat(value)
+			if err != nil {
+				return nil, fmt.Errorf("watermark parse failed: %w", err)
+			}
+
+			if collector.metricFilter.SeriesEnabled(bufferPoolWatermarkMetricName, "pool", pool, "type", watermarkType, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(collector.watermark, prometheus.GaugeValue, watermark, pool, watermarkType, namespace))
+			}
+
+			if size > 0 && collector.metricFilter.SeriesEnabled(bufferPoolUtilizationMetricName, "pool", pool, "type", watermarkType, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(collector.utilization, prometheus.GaugeValue, watermark/size, pool, watermarkType, namespace))
+			}
+		}
+
+		if value, ok := stats[watermarkType][bufferPoolHeadroomWatermarkField]; ok {
+			headroomWatermark, err := parseFloat(value)
+			if err != nil {
+				return nil, fmt.Errorf("headroom watermark parse failed: %w", err)
+			}
+
+			if collector.metricFilter.SeriesEnabled(bufferPoolHeadroomWatermarkMetricName, "pool", pool, "type", watermarkType, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(collector.headroomWatermark, prometheus.GaugeValue, headroomWatermark, pool, watermarkType, namespace))
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+func loadBufferPoolCollectorConfig(logger *slog.Logger) bufferPoolCollectorConfig {
+	return bufferPoolCollectorConfig{
+		enabled:         parseBoolEnv(logger, "BUFFER_POOL_ENABLED", false),
+		refreshInterval: parseDurationEnv(logger, "BUFFER_POOL_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "BUFFER_POOL_TIMEOUT", 2*time.Second),
+		redisScanCount:  256,
+	}
+}
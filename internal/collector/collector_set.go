@@ -0,0 +1,168 @@
+package collector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/internal/config"
+)
+
+// CollectorSet serves the enabled collectors and replaces them on Reload.
+// It is registered as an unchecked collector because the set of collectors
+// and metric families changes with the configuration.
+type CollectorSet struct {
+	lastReloadSuccess     *prometheus.Desc
+	lastReloadSuccessTime *prometheus.Desc
+	seriesFiltered        *prometheus.Desc
+
+	options    CollectorOptions
+	flags      CollectorFlags
+	configFile string
+
+	// reloadMu serializes reloads, mu guards the fields below
+	reloadMu              sync.Mutex
+	mu                    sync.RWMutex
+	collectors            []SonicCollector
+	metricFilter          MetricFilter
+	lastSuccess           float64
+	lastSuccessReloadTime time.Time
+}
+
+// NewCollectorSet builds every enabled collector. configFile is loaded again
+// on every Reload, options.MetricFilter is rebuilt from the reloaded settings.
+func NewCollectorSet(options CollectorOptions, flags CollectorFlags, configFile string) *CollectorSet {
+	const (
+		namespace = "sonic"
+		subsystem = "exporter"
+	)
+
+	collectors := NewCollectors(options, flags)
+	logActiveMetrics(options.Logger, options.MetricFilter, collectors)
+
+	return &CollectorSet{
+		lastReloadSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "config_last_reload_success"),
+			"Whether the last configuration reload succeeded", nil, nil),
+		lastReloadSuccessTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "config_last_reload_success_timestamp_seconds"),
+			"Timestamp of the last successful configuration reload", nil, nil),
+		seriesFiltered: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "series_filtered_total"),
+			"Number of series dropped by metric label rules", []string{"metric"}, nil),
+		options:               options,
+		flags:                 flags,
+		configFile:            configFile,
+		collectors:            collectors,
+		metricFilter:          options.MetricFilter,
+		lastSuccess:           1,
+		lastSuccessReloadTime: time.Now(),
+	}
+}
+
+// Collectors returns the current collectors in registration order.
+func (set *CollectorSet) Collectors() []SonicCollector {
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+
+	return append([]SonicCollector{}, set.collectors...)
+}
+
+// MetricFilter returns the metric filter of the current configuration.
+func (set *CollectorSet) MetricFilter() MetricFilter {
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+
+	return set.metricFilter
+}
+
+// Reload loads the configuration file and env again and replaces all
+// collectors, buildOnce collectors only get the new metric filter. New
+// collectors complete their first refresh before they are swapped in, so
+// scrapes keep being served from the previous caches until then. On failure
+// the current collectors keep running unchanged.
+func (set *CollectorSet) Reload() error {
+	set.reloadMu.Lock()
+	defer set.reloadMu.Unlock()
+
+	if err := config.Load(set.configFile); err != nil {
+		set.mu.Lock()
+		set.lastSuccess = 0
+		set.mu.Unlock()
+
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	metricFilter, err := NewMetricFilter(set.options.Logger)
+	if err != nil {
+		set.mu.Lock()
+		set.lastSuccess = 0
+		set.mu.Unlock()
+
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	options := set.options
+	options.MetricFilter = metricFilter
+	// Keep counting filtered series across reloads
+	options.MetricFilter.filtered = set.metricFilter.filtered
+	set.mu.RLock()
+	previous := set.collectors
+	set.mu.RUnlock()
+
+	collectors := reloadCollectors(options, set.flags, previous)
+	logActiveMetrics(options.Logger, options.MetricFilter, collectors)
+
+	set.mu.Lock()
+	set.collectors = collectors
+	set.metricFilter = options.MetricFilter
+	set.lastSuccess = 1
+	set.lastSuccessReloadTime = time.Now()
+	set.mu.Unlock()
+
+	for _, collector := range previous {
+		if _, ok := collector.(metricFilterReplacer); ok {
+			// Still running in its copy with the new metric filter
+			continue
+		}
+		collector.Stop()
+	}
+
+	set.options.Logger.Info("Configuration reloaded", "collectors", len(collectors))
+
+	return nil
+}
+
+// Describe sends no descriptors, which makes the set an unchecked collector.
+func (set *CollectorSet) Describe(ch chan<- *prometheus.Desc) {}
+
+func (set *CollectorSet) Collect(ch chan<- prometheus.Metric) {
+	set.mu.RLock()
+	collectors := set.collectors
+	metricFilter := set.metricFilter
+	lastSuccess := set.lastSuccess
+	lastSuccessReloadTime := set.lastSuccessReloadTime
+	set.mu.RUnlock()
+
+	// Collect concurrently like the registry does for registered collectors
+	var wg sync.WaitGroup
+	for _, collector := range collectors {
+		wg.Add(1)
+		go func(collector SonicCollector) {
+			defer wg.Done()
+			collector.Collect(ch)
+		}(collector)
+	}
+	wg.Wait()
+
+	if metricFilter.Enabled("sonic_exporter_config_last_reload_success") {
+		ch <- prometheus.MustNewConstMetric(set.lastReloadSuccess, prometheus.GaugeValue, lastSuccess)
+	}
+	if metricFilter.Enabled("sonic_exporter_config_last_reload_success_timestamp_seconds") {
+		ch <- prometheus.MustNewConstMetric(set.lastReloadSuccessTime, prometheus.GaugeValue, float64(lastSuccessReloadTime.Unix()))
+	}
+	if metricFilter.Enabled("sonic_exporter_series_filtered_total") {
+		names, counts := metricFilter.filtered.snapshot()
+		for _, name := range names {
+			ch <- prometheus.MustNewConstMetric(set.seriesFiltered, prometheus.CounterValue, counts[name], name)
+		}
+	}
+}
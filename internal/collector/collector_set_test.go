@@ -0,0 +1,261 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/promslog"
+)
+
+func collectorNames(collectors []SonicCollector) map[string]bool {
+	names := map[string]bool{}
+	for _, collector := range collectors {
+		names[collector.Name()] = true
+	}
+
+	return names
+}
+
+func TestCollectorSetReload(t *testing.T) {
+	app := kingpin.New("test", "")
+	flags := AddCollectorFlags(app)
+	if _, err := app.Parse([]string{"--collector.vlan"}); err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+
+	t.Setenv("LLDP_ENABLED", "true")
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, flags, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+
+	if names := collectorNames(set.Collectors()); !names["lldp"] || !names["vlan"] {
+		t.Fatalf("collectors before reload = %v, want lldp and vlan", names)
+	}
+
+	t.Setenv("LLDP_ENABLED", "false")
+	t.Setenv("VLAN_ENABLED", "false")
+	t.Setenv("SONIC_DISABLED_METRICS", "sonic_exporter_config_last_reload_success_timestamp_seconds")
+	if err := set.Reload(); err != nil {
+		t.Fatalf("Reload error = %v", err)
+	}
+
+	names := collectorNames(set.Collectors())
+	if names["lldp"] {
+		t.Error("lldp collector still running after LLDP_ENABLED=false reload")
+	}
+	if !names["vlan"] {
+		t.Error("vlan collector stopped by reload, want --collector.vlan to override VLAN_ENABLED")
+	}
+
+	expected := `
+# HELP sonic_exporter_config_last_reload_success Whether the last configuration reload succeeded
+# TYPE sonic_exporter_config_last_reload_success gauge
+sonic_exporter_config_last_reload_success 1
+`
+	if err := testutil.CollectAndCompare(set, strings.NewReader(expected),
+		"sonic_exporter_config_last_reload_success",
+		"sonic_exporter_config_last_reload_success_timestamp_seconds"); err != nil {
+		t.Errorf("unexpected reload metrics after reload: %v", err)
+	}
+}
+
+func TestCollectorSetReloadKeepsCollectorsOnError(t *testing.T) {
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, CollectorFlags{}, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+	before := collectorNames(set.Collectors())
+
+	t.Setenv("LLDP_REFRESH_INTERVAL", "often")
+	t.Setenv("LLDP_ENABLED", "false")
+	if err := set.Reload(); err == nil || !strings.Contains(err.Error(), "LLDP_REFRESH_INTERVAL") {
+		t.Fatalf("Reload error = %v, want invalid LLDP_REFRESH_INTERVAL", err)
+	}
+
+	if after := collectorNames(set.Collectors()); len(after) != len(before) || !after["lldp"] {
+		t.Errorf("collectors after failed reload = %v, want %v", after, before)
+	}
+
+	expected := `
+# HELP sonic_exporter_config_last_reload_success Whether the last configuration reload succeeded
+# TYPE sonic_exporter_config_last_reload_success gauge
+sonic_exporter_config_last_reload_success 0
+`
+	if err := testutil.CollectAndCompare(set, strings.NewReader(expected), "sonic_exporter_config_last_reload_success"); err != nil {
+		t.Errorf("unexpected reload metrics after failed reload: %v", err)
+	}
+}
+
+func TestCollectorSetReloadRejectsInvalidLabelRules(t *testing.T) {
+	t.Setenv("SONIC_METRIC_LABEL_RULES", "")
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, CollectorFlags{}, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+	before := set.Collectors()
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `drop sonic_queue_*{device="Ethernet0"`)
+	if err := set.Reload(); err == nil || !strings.Contains(err.Error(), "SONIC_METRIC_LABEL_RULES") {
+		t.Fatalf("Reload error = %v, want invalid SONIC_METRIC_LABEL_RULES", err)
+	}
+
+	if after := set.Collectors(); len(after) != len(before) || after[0] != before[0] {
+		t.Error("collectors replaced by a reload with invalid label rules")
+	}
+
+	expected := `
+# HELP sonic_exporter_config_last_reload_success Whether the last configuration reload succeeded
+# TYPE sonic_exporter_config_last_reload_success gauge
+sonic_exporter_config_last_reload_success 0
+`
+	if err := testutil.CollectAndCompare(set, strings.NewReader(expected), "sonic_exporter_config_last_reload_success"); err != nil {
+		t.Errorf("unexpected reload metrics after failed reload: %v", err)
+	}
+}
+
+func TestCollectorSetReloadKeepsFrrCollector(t *testing.T) {
+	t.Setenv("FRR_ENABLED", "true")
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, CollectorFlags{}, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+
+	findFrr := func() *frrCollector {
+		for _, collector := range set.Collectors() {
+			if frr, ok := collector.(*frrCollector); ok {
+				return frr
+			}
+		}
+		return nil
+	}
+	before := findFrr()
+	if before == nil {
+		t.Fatal("frr collector not built with FRR_ENABLED=true")
+	}
+
+	// FRR settings are read at startup only, the exporter is not built again
+	t.Setenv("FRR_ENABLED", "false")
+	t.Setenv("FRR_SOCKET_DIR_PATH", "/srv/frr")
+	t.Setenv("SONIC_DISABLED_METRICS", "frr_*")
+	if err := set.Reload(); err != nil {
+		t.Fatalf("Reload error = %v", err)
+	}
+
+	after := findFrr()
+	if after == nil {
+		t.Fatal("frr collector dropped by reload")
+	}
+	if after.exporter != before.exporter || after.config.socketDirPath != before.config.socketDirPath {
+		t.Error("frr exporter rebuilt by reload")
+	}
+	if after.metricFilter.Enabled("frr_bgp_peer_state") {
+		t.Error("frr collector kept the metric filter from before the reload")
+	}
+}
+
+func TestCollectorSetReloadKeepsLagCounters(t *testing.T) {
+	app := kingpin.New("test", "")
+	flags := AddCollectorFlags(app)
+	args := []string{}
+	for _, factory := range collectorFactories {
+		if factory.name == "lag" {
+			args = append(args, "--collector.lag")
+		} else {
+			args = append(args, "--no-collector."+factory.name)
+		}
+	}
+	if _, err := app.Parse(args); err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(0).HSet("LAG_TABLE:PortChannel10", "oper_status", "up")
+	asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet0", "status", "enabled")
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001", "Ethernet4", "oid:0x1000000000002")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "1000")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000002", "SAI_PORT_STAT_IF_IN_OCTETS", "500")
+
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_lag_*{namespace="asic0"}`)
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: redisClient}, flags, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+
+	// Ethernet4 joins, a reload must not start the LAG again from the member sum
+	asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet4", "status", "enabled")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "1100")
+	if err := set.Reload(); err != nil {
+		t.Fatalf("Reload error = %v", err)
+	}
+
+	expected := `
+# HELP sonic_lag_receive_bytes_total Number of bytes received on LAG member interfaces
+# TYPE sonic_lag_receive_bytes_total counter
+sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1100
+`
+	if err := testutil.CollectAndCompare(set, strings.NewReader(expected), "sonic_lag_receive_bytes_total"); err != nil {
+		t.Errorf("unexpected LAG counters after reload: %v", err)
+	}
+}
+
+func TestCollectorSetReloadKeepsFecSamples(t *testing.T) {
+	app := kingpin.New("test", "")
+	flags := AddCollectorFlags(app)
+	args := []string{}
+	for _, factory := range collectorFactories {
+		if factory.name == "interface" {
+			args = append(args, "--collector.interface")
+		} else {
+			args = append(args, "--no-collector."+factory.name)
+		}
+	}
+	if _, err := app.Parse(args); err != nil {
+		t.Fatalf("Parse error = %v", err)
+	}
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_FEC_CORRECTED_BITS", "1000")
+	asic0.DB(4).HSet("PORT|Ethernet0", "speed", "100000")
+
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_interface_*{namespace="asic0"}`)
+	logger := promslog.New(&promslog.Config{})
+	set := NewCollectorSet(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: redisClient}, flags, "")
+	t.Cleanup(func() {
+		for _, collector := range set.Collectors() {
+			collector.Stop()
+		}
+	})
+
+	// The first refresh after a reload computes the BER since the sample of
+	// the replaced collector
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_FEC_CORRECTED_BITS", "2000")
+	if err := set.Reload(); err != nil {
+		t.Fatalf("Reload error = %v", err)
+	}
+
+	assertMetricFamilyPresence(t, set, "sonic_interface_fec_pre_ber", true)
+}
@@ -3,9 +3,11 @@ package collector
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -20,6 +22,19 @@ import (
 	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
+// newTestMetricFilter builds the MetricFilter of the current env and fails
+// the test when it is invalid.
+func newTestMetricFilter(t *testing.T, logger *slog.Logger) MetricFilter {
+	t.Helper()
+
+	filter, err := NewMetricFilter(logger)
+	if err != nil {
+		t.Fatalf("NewMetricFilter() error = %v", err)
+	}
+
+	return filter
+}
+
 func assertMetricFamilyPresence(t *testing.T, c prometheus.Collector, metricName string, wantPresent bool) {
 	t.Helper()
 
@@ -156,6 +171,9 @@ func collectMetricFamilyNamesWithPrefix(t *testing.T, c prometheus.Collector, pr
 	return names
 }
 
+// testRedisClient is shared by all collectors under test, mirroring main.
+var testRedisClient redis.Client
+
 type redisDatabase struct {
 	DbId string                       `json:"id"`
 	Data map[string]map[string]string `json:"data"`
@@ -185,8 +203,6 @@ func populateRedisData() error {
 func pushDataFromFile(ctx context.Context, fileName string) error {
 	var database redisDatabase
 
-	redisClient, _ := redis.NewClient()
-
 	file, _ := os.Open(fileName)
 	defer file.Close()
 
@@ -200,7 +216,7 @@ func pushDataFromFile(ctx context.Context, fileName string) error {
 	}
 
 	for key, values := range database.Data {
-		err := redisClient.HsetToDb(ctx, database.DbId, key, values)
+		err := testRedisClient.HsetToDb(ctx, database.DbId, key, values)
 		if err != nil {
 			return err
 		}
@@ -234,6 +250,13 @@ func TestMain(m *testing.M) {
 	os.Setenv("SYSTEM_MACHINE_CONF_FILE", "../../fixtures/test/system_machine.conf")
 	os.Setenv("SYSTEM_HOSTNAME_FILE", "../../fixtures/test/system_hostname")
 	os.Setenv("SYSTEM_UPTIME_FILE", "../../fixtures/test/system_uptime")
+
+	testRedisClient, err = redis.NewClient()
+	if err != nil {
+		slog.Error("failed to create redis client", "error", err)
+		os.Exit(1)
+	}
+
 	err = populateRedisData()
 	if err != nil {
 		slog.Error("failed to populate redis data", "error", err)
@@ -242,6 +265,7 @@ func TestMain(m *testing.M) {
 
 	exitCode := m.Run()
 
+	testRedisClient.Close()
 	s.Close()
 	os.Unsetenv("REDIS_ADDRESS")
 	os.Unsetenv("LLDP_ENABLED")
@@ -268,7 +292,7 @@ func TestInterfaceCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	interfaceCollector := NewInterfaceCollector(logger, NewMetricFilter(logger))
+	interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(interfaceCollector)
 	if err != nil {
@@ -289,7 +313,7 @@ func TestInterfaceCollector(t *testing.T) {
 
 	expected := `
 
-		sonic_interface_collector_success 1
+		sonic_interface_collector_success{namespace=""} 1
 	`
 	success_metric := "sonic_interface_collector_success"
 
@@ -302,7 +326,7 @@ func TestHwCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	hwCollector := NewHwCollector(logger, NewMetricFilter(logger))
+	hwCollector := NewHwCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(hwCollector)
 	if err != nil {
@@ -356,7 +380,7 @@ func TestHwCollectorMetricFilter(t *testing.T) {
 	logger := promslog.New(promslogConfig)
 
 	t.Run("default emits hw metrics", func(t *testing.T) {
-		hwCollector := NewHwCollector(logger, NewMetricFilter(logger))
+		hwCollector := NewHwCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_fan_rpm", true)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_psu_voltage_volts", true)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_psu_current_amperes", true)
@@ -380,7 +404,7 @@ func TestHwCollectorMetricFilter(t *testing.T) {
 
 	t.Run("wildcard disable removes fan metric families", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_hw_fan_*")
-		hwCollector := NewHwCollector(logger, NewMetricFilter(logger))
+		hwCollector := NewHwCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_fan_rpm", false)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_fan_operational_status", false)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_fan_available_status", false)
@@ -389,7 +413,7 @@ func TestHwCollectorMetricFilter(t *testing.T) {
 
 	t.Run("exact disable removes hw scrape duration metric", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_hw_scrape_duration_seconds")
-		hwCollector := NewHwCollector(logger, NewMetricFilter(logger))
+		hwCollector := NewHwCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, hwCollector, "sonic_hw_scrape_duration_seconds", false)
 	})
 }
@@ -417,9 +441,7 @@ func TestHwCollectorPsuNumericMetricParsing(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	hwCollector := NewHwCollector(logger, NewMetricFilter(logger))
-	hwCollector.lastScrapeTime = time.Time{}
-	hwCollector.cachedMetrics = nil
+	hwCollector := NewHwCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	voltageFamily := getMetricFamily(t, hwCollector, "sonic_hw_psu_voltage_volts")
 	if hasPsuSlotInMetricFamily(voltageFamily, "3") {
@@ -445,7 +467,7 @@ func TestCrmCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	crmCollector := NewCrmCollector(logger, NewMetricFilter(logger))
+	crmCollector := NewCrmCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(crmCollector)
 	if err != nil {
@@ -466,7 +488,7 @@ func TestCrmCollector(t *testing.T) {
 
 	expected := `
 
-	 sonic_crm_collector_success 1
+	 sonic_crm_collector_success{namespace=""} 1
 	`
 	success_metric := "sonic_crm_collector_success"
 
@@ -480,14 +502,14 @@ func TestCrmCollectorMetricFilter(t *testing.T) {
 	logger := promslog.New(promslogConfig)
 
 	t.Run("default emits crm metrics", func(t *testing.T) {
-		crmCollector := NewCrmCollector(logger, NewMetricFilter(logger))
+		crmCollector := NewCrmCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, crmCollector, "sonic_crm_resource_used", true)
 		assertMetricFamilyPresence(t, crmCollector, "sonic_crm_acl_resource_used", true)
 	})
 
 	t.Run("wildcard disable removes resource metric families", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_crm_resource_*")
-		crmCollector := NewCrmCollector(logger, NewMetricFilter(logger))
+		crmCollector := NewCrmCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, crmCollector, "sonic_crm_resource_used", false)
 		assertMetricFamilyPresence(t, crmCollector, "sonic_crm_resource_available", false)
 		assertMetricFamilyPresence(t, crmCollector, "sonic_crm_acl_resource_used", true)
@@ -499,7 +521,7 @@ func TestQueueCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	queueCollector := NewQueueCollector(logger, NewMetricFilter(logger))
+	queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(queueCollector)
 	if err != nil {
@@ -520,7 +542,7 @@ func TestQueueCollector(t *testing.T) {
 
 	expected := `
 
-	 sonic_queue_collector_success 1
+	 sonic_queue_collector_success{namespace=""} 1
 	`
 	success_metric := "sonic_queue_collector_success"
 
@@ -534,46 +556,335 @@ func TestQueueCollectorMetricFilter(t *testing.T) {
 	logger := promslog.New(promslogConfig)
 
 	t.Run("default emits queue watermark metric", func(t *testing.T) {
-		queueCollector := NewQueueCollector(logger, NewMetricFilter(logger))
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_watermark_bytes_total", true)
 	})
 
 	t.Run("exact disable removes queue watermark metric", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_queue_watermark_bytes_total")
-		queueCollector := NewQueueCollector(logger, NewMetricFilter(logger))
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_watermark_bytes_total", false)
 	})
 
 	t.Run("wildcard disable removes queue metric families", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_queue_*")
-		queueCollector := NewQueueCollector(logger, NewMetricFilter(logger))
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_watermark_bytes_total", false)
 		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_packets_total", false)
 		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_collector_success", false)
 	})
 }
 
+func TestQueueCollectorQueueType(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	metadata := `
+		# HELP sonic_queue_packets_total Number of packets in a queue
+		# TYPE sonic_queue_packets_total counter
+	`
+
+	expected := `
+		sonic_queue_packets_total{device="Ethernet0",namespace="",queue="0",queue_type="unicast"} 2
+		sonic_queue_packets_total{device="Ethernet0",namespace="",queue="1",queue_type="unicast"} 2
+		sonic_queue_packets_total{device="Ethernet1",namespace="",queue="13",queue_type="multicast"} 2
+		sonic_queue_packets_total{device="Ethernet1",namespace="",queue="15",queue_type="unknown"} 2
+	`
+
+	if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(metadata+expected), "sonic_queue_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestQueueCollectorSkipsMalformedNameMapKeys(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_QUEUE_NAME_MAP", "Ethernet0:3", "oid:0x15000000000001", "Ethernet4", "oid:0x15000000000002", ":1", "oid:0x15000000000003")
+	asic0.DB(2).HSet("COUNTERS:oid:0x15000000000001", "SAI_QUEUE_STAT_PACKETS", "7")
+
+	queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	successFamily := getMetricFamily(t, queueCollector, "sonic_queue_collector_success")
+	if !metricWithLabelsExists(successFamily, map[string]string{"namespace": "asic0"}, 1) {
+		t.Errorf("expected sonic_queue_collector_success 1 for asic0 namespace")
+	}
+
+	skippedFamily := getMetricFamily(t, queueCollector, "sonic_queue_entries_skipped")
+	if !metricWithLabelsExists(skippedFamily, map[string]string{}, 2) {
+		t.Errorf("expected sonic_queue_entries_skipped 2")
+	}
+
+	metadata := `
+		# HELP sonic_queue_packets_total Number of packets in a queue
+		# TYPE sonic_queue_packets_total counter
+	`
+
+	expected := `
+		sonic_queue_packets_total{device="Ethernet0",namespace="asic0",queue="3",queue_type="unknown"} 7
+	`
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_queue_packets_total{namespace="asic0"}`)
+	queueCollector = NewQueueCollector(logger, newTestMetricFilter(t, logger), redisClient)
+	if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(metadata+expected), "sonic_queue_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestQueueCollectorWatermarkGauges(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `drop sonic_queue_*{device="Ethernet1"}; drop sonic_queue_*{queue="1"}`)
+
+	metadata := `
+		# HELP sonic_queue_shared_watermark_bytes Shared buffer watermark of a queue
+		# TYPE sonic_queue_shared_watermark_bytes gauge
+		# HELP sonic_queue_watermark_bytes Buffer watermark of a queue by watermark type: user, persistent, periodic
+		# TYPE sonic_queue_watermark_bytes gauge
+		# HELP sonic_queue_watermark_periodic_interval_seconds Interval at which periodic watermarks are read and cleared
+		# TYPE sonic_queue_watermark_periodic_interval_seconds gauge
+	`
+
+	// USER_WATERMARKS of queue 0 has a second field sorting before the shared watermark
+	expected := `
+		sonic_queue_shared_watermark_bytes{device="Ethernet0",namespace="",queue="0",queue_type="unicast"} 5554
+		sonic_queue_watermark_bytes{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="periodic"} 111
+		sonic_queue_watermark_bytes{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="persistent"} 222
+		sonic_queue_watermark_bytes{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="user"} 124
+		sonic_queue_watermark_periodic_interval_seconds{namespace=""} 30
+	`
+
+	queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+	if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(metadata+expected),
+		"sonic_queue_shared_watermark_bytes", "sonic_queue_watermark_bytes", "sonic_queue_watermark_periodic_interval_seconds"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	t.Run("label rules match the watermark type", func(t *testing.T) {
+		t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_queue_watermark_bytes{type="periodic"}; drop sonic_queue_*{device="Ethernet1"}; drop sonic_queue_*{queue="1"}`)
+
+		expected := `
+			sonic_queue_watermark_bytes{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="periodic"} 111
+		`
+
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(metadata+expected), "sonic_queue_watermark_bytes"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+	})
+
+	t.Run("legacy families are exported by default", func(t *testing.T) {
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_shared_watermark_bytes_total", true)
+
+		// The deprecated family keeps its watermark label
+		legacyMetadata := `
+			# HELP sonic_queue_watermark_bytes_total Deprecated: use sonic_queue_watermark_bytes
+			# TYPE sonic_queue_watermark_bytes_total counter
+		`
+		legacyExpected := `
+			sonic_queue_watermark_bytes_total{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="periodic",watermark="shared"} 111
+			sonic_queue_watermark_bytes_total{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="persistent",watermark="shared"} 222
+			sonic_queue_watermark_bytes_total{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="user",watermark="delay_watermark_ns"} 9000
+			sonic_queue_watermark_bytes_total{device="Ethernet0",namespace="",queue="0",queue_type="unicast",type="user",watermark="shared"} 124
+		`
+		if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(legacyMetadata+legacyExpected), "sonic_queue_watermark_bytes_total"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+	})
+
+	t.Run("queues without the shared watermark are skipped", func(t *testing.T) {
+		redisClient, asic0 := newNamespacedTestRedisClient(t)
+		asic0.DB(2).HSet("COUNTERS_QUEUE_NAME_MAP", "Ethernet0:0", "oid:0x15000000000001", "Ethernet0:1", "oid:0x15000000000002")
+		asic0.DB(2).HSet("USER_WATERMARKS:oid:0x15000000000001", "SAI_QUEUE_STAT_SHARED_WATERMARK_BYTES", "64")
+		asic0.DB(2).HSet("USER_WATERMARKS:oid:0x15000000000002", "SAI_QUEUE_STAT_DELAY_WATERMARK_NS", "9000")
+
+		t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_*{namespace="asic0"}`)
+		expected := `
+			sonic_queue_watermark_bytes{device="Ethernet0",namespace="asic0",queue="0",queue_type="unknown",type="user"} 64
+		`
+
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), redisClient)
+		if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(metadata+expected), "sonic_queue_watermark_bytes"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+	})
+
+	t.Run("compatibility flag off drops legacy families", func(t *testing.T) {
+		t.Setenv("QUEUE_LEGACY_WATERMARK_METRICS", "false")
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_watermark_bytes_total", false)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_shared_watermark_bytes_total", false)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_queue_watermark_bytes", true)
+	})
+}
+
+func TestQueueCollectorResolvesPortFromPortMap(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet4", "oid:0x1000000000002")
+	// The first queue belongs to Ethernet4 whatever its key says, the second
+	// has no port in the port map and keeps the device of its key
+	asic0.DB(2).HSet("COUNTERS_QUEUE_NAME_MAP", "Ethernet0:3", "oid:0x15000000000001", "Ethernet8:1", "oid:0x15000000000002")
+	asic0.DB(2).HSet("COUNTERS_QUEUE_PORT_MAP", "oid:0x15000000000001", "oid:0x1000000000002")
+	asic0.DB(2).HSet("COUNTERS:oid:0x15000000000001", "SAI_QUEUE_STAT_PACKETS", "7")
+	asic0.DB(2).HSet("COUNTERS:oid:0x15000000000002", "SAI_QUEUE_STAT_PACKETS", "9")
+	asic0.DB(2).HSet("COUNTERS_PG_NAME_MAP", "Ethernet0:0", "oid:0x1a000000000001")
+	asic0.DB(2).HSet("COUNTERS_PG_PORT_MAP", "oid:0x1a000000000001", "oid:0x1000000000002")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1a000000000001", "SAI_INGRESS_PRIORITY_GROUP_STAT_PACKETS", "5")
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_*{namespace="asic0"}`)
+	queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	expected := `
+		# HELP sonic_queue_packets_total Number of packets in a queue
+		# TYPE sonic_queue_packets_total counter
+		sonic_queue_packets_total{device="Ethernet4",namespace="asic0",queue="3",queue_type="unknown"} 7
+		sonic_queue_packets_total{device="Ethernet8",namespace="asic0",queue="1",queue_type="unknown"} 9
+	`
+
+	if err := testutil.CollectAndCompare(queueCollector, strings.NewReader(expected), "sonic_queue_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	priorityGroupCollector := NewPriorityGroupCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	expected = `
+		# HELP sonic_priority_group_packets_total Number of packets received in an ingress priority group
+		# TYPE sonic_priority_group_packets_total counter
+		sonic_priority_group_packets_total{device="Ethernet4",namespace="asic0",pg="0"} 5
+	`
+
+	if err := testutil.CollectAndCompare(priorityGroupCollector, strings.NewReader(expected), "sonic_priority_group_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestPriorityGroupCollector(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	priorityGroupCollector := NewPriorityGroupCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	problems, err := testutil.CollectAndLint(priorityGroupCollector)
+	if err != nil {
+		t.Error("metric lint completed with errors")
+	}
+
+	for _, problem := range problems {
+		t.Errorf("metric %v has a problem: %v", problem.Metric, problem.Text)
+	}
+
+	metadata := `
+		# HELP sonic_priority_group_bytes_total Number of bytes received in an ingress priority group
+		# TYPE sonic_priority_group_bytes_total counter
+		# HELP sonic_priority_group_collector_success Whether priority group collector succeeded
+		# TYPE sonic_priority_group_collector_success gauge
+		# HELP sonic_priority_group_dropped_packets_total Number of packets dropped in an ingress priority group
+		# TYPE sonic_priority_group_dropped_packets_total counter
+		# HELP sonic_priority_group_entries_skipped Number of priority group name map entries skipped during latest refresh
+		# TYPE sonic_priority_group_entries_skipped gauge
+		# HELP sonic_priority_group_packets_total Number of packets received in an ingress priority group
+		# TYPE sonic_priority_group_packets_total counter
+		# HELP sonic_priority_group_watermark_bytes Buffer watermark of an ingress priority group
+		# TYPE sonic_priority_group_watermark_bytes gauge
+	`
+
+	expected := `
+		sonic_priority_group_bytes_total{device="Ethernet0",namespace="",pg="0"} 128000
+		sonic_priority_group_bytes_total{device="Ethernet0",namespace="",pg="3"} 7.5e+06
+		sonic_priority_group_collector_success{namespace=""} 1
+		sonic_priority_group_dropped_packets_total{device="Ethernet0",namespace="",pg="0"} 0
+		sonic_priority_group_dropped_packets_total{device="Ethernet0",namespace="",pg="3"} 4
+		sonic_priority_group_entries_skipped 0
+		sonic_priority_group_packets_total{device="Ethernet0",namespace="",pg="0"} 1000
+		sonic_priority_group_packets_total{device="Ethernet0",namespace="",pg="3"} 5000
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="periodic",watermark="shared"} 1024
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="periodic",watermark="xoff_room"} 0
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="persistent",watermark="shared"} 4096
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="persistent",watermark="xoff_room"} 0
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="user",watermark="shared"} 2048
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="0",type="user",watermark="xoff_room"} 0
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="periodic",watermark="shared"} 30720
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="periodic",watermark="xoff_room"} 4608
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="persistent",watermark="shared"} 92160
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="persistent",watermark="xoff_room"} 18432
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="user",watermark="shared"} 61440
+		sonic_priority_group_watermark_bytes{device="Ethernet0",namespace="",pg="3",type="user",watermark="xoff_room"} 9216
+	`
+
+	if err := testutil.CollectAndCompare(priorityGroupCollector, strings.NewReader(metadata+expected),
+		"sonic_priority_group_bytes_total", "sonic_priority_group_collector_success", "sonic_priority_group_dropped_packets_total",
+		"sonic_priority_group_entries_skipped", "sonic_priority_group_packets_total", "sonic_priority_group_watermark_bytes"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	t.Run("disabled watermark family is not exported", func(t *testing.T) {
+		t.Setenv("SONIC_DISABLED_METRICS", "sonic_priority_group_watermark_bytes")
+		priorityGroupCollector := NewPriorityGroupCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, priorityGroupCollector, "sonic_priority_group_watermark_bytes", false)
+		assertMetricFamilyPresence(t, priorityGroupCollector, "sonic_priority_group_packets_total", true)
+	})
+
+	t.Run("queue collector exports no priority groups", func(t *testing.T) {
+		queueCollector := NewQueueCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_priority_group_packets_total", false)
+		assertMetricFamilyPresence(t, queueCollector, "sonic_priority_group_watermark_bytes", false)
+	})
+}
+
 func TestInterfaceCollectorMetricFilter(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
 	t.Run("default emits interface mtu metric", func(t *testing.T) {
-		interfaceCollector := NewInterfaceCollector(logger, NewMetricFilter(logger))
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, interfaceCollector, "sonic_interface_mtu_bytes", true)
 	})
 
 	t.Run("exact disable removes interface mtu metric", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_interface_mtu_bytes")
-		interfaceCollector := NewInterfaceCollector(logger, NewMetricFilter(logger))
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, interfaceCollector, "sonic_interface_mtu_bytes", false)
 	})
 }
 
+func TestLldpCollectorLabelRules(t *testing.T) {
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `drop sonic_lldp_neighbor_info{local_role="management"}`)
+
+	logger := promslog.New(&promslog.Config{})
+	metricFilter := newTestMetricFilter(t, logger)
+	lldpCollector := NewLldpCollector(logger, metricFilter, testRedisClient)
+
+	metadata := `
+		# HELP sonic_lldp_neighbor_info Non-numeric data about LLDP neighbor, value is always 1
+		# TYPE sonic_lldp_neighbor_info gauge
+	`
+
+	expected := `
+		sonic_lldp_neighbor_info{local_interface="Ethernet88",local_role="frontpanel",remote_chassis_id="74:86:e2:6d:df:a5",remote_mgmt_ip="192.168.240.123",remote_port_desc="Ethernet88",remote_port_display="Ethernet88",remote_port_id="hundredGigE1/23",remote_port_id_subtype="7",remote_system_name="net-tor-lab001.lau1"} 1
+	`
+
+	if err := testutil.CollectAndCompare(lldpCollector, strings.NewReader(metadata+expected), "sonic_lldp_neighbor_info"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	if _, counts := metricFilter.filtered.snapshot(); counts["sonic_lldp_neighbor_info"] != 1 {
+		t.Errorf("filtered sonic_lldp_neighbor_info = %v, want 1", counts["sonic_lldp_neighbor_info"])
+	}
+}
+
 func TestLldpCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	lldpCollector := NewLldpCollector(logger, NewMetricFilter(logger))
+	lldpCollector := NewLldpCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(lldpCollector)
 	if err != nil {
@@ -635,7 +946,7 @@ func TestRoutingCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	routingCollector := NewRoutingCollector(logger, NewMetricFilter(logger))
+	routingCollector := NewRoutingCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(routingCollector)
 	if err != nil {
@@ -667,7 +978,7 @@ func TestSwitchCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	switchCollector := NewSwitchCollector(logger, NewMetricFilter(logger))
+	switchCollector := NewSwitchCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(switchCollector)
 	if err != nil {
@@ -697,7 +1008,7 @@ func TestThermalCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	thermalCollector := NewThermalCollector(logger, NewMetricFilter(logger))
+	thermalCollector := NewThermalCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(thermalCollector)
 	if err != nil {
@@ -728,7 +1039,7 @@ func TestTransceiverCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	transceiverCollector := NewTransceiverCollector(logger, NewMetricFilter(logger))
+	transceiverCollector := NewTransceiverCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(transceiverCollector)
 	if err != nil {
@@ -744,7 +1055,7 @@ func TestTransceiverCollector(t *testing.T) {
 		# TYPE sonic_transceiver_module_info gauge
 	`
 	infoExpected := `
-		sonic_transceiver_module_info{device="Ethernet0",module_fault_cause="No Fault detected",module_state="ModuleReady"} 1
+		sonic_transceiver_module_info{device="Ethernet0",module_fault_cause="No Fault detected",module_state="ModuleReady",namespace=""} 1
 	`
 	if err := testutil.CollectAndCompare(transceiverCollector, strings.NewReader(infoMetadata+infoExpected), "sonic_transceiver_module_info"); err != nil {
 		t.Errorf("unexpected collecting result:\n%s", err)
@@ -771,7 +1082,7 @@ func TestPlatformHealthCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	platformCollector := NewPlatformHealthCollector(logger, NewMetricFilter(logger))
+	platformCollector := NewPlatformHealthCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(platformCollector)
 	if err != nil {
@@ -810,7 +1121,7 @@ func TestVlanCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	vlanCollector := NewVlanCollector(logger, NewMetricFilter(logger))
+	vlanCollector := NewVlanCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(vlanCollector)
 	if err != nil {
@@ -829,9 +1140,9 @@ func TestVlanCollector(t *testing.T) {
 	`
 
 	expected := `
-		sonic_vlan_collector_success 1
-		sonic_vlan_members{vlan="Vlan1000"} 2
-		sonic_vlan_members{vlan="Vlan2000"} 0
+		sonic_vlan_collector_success{namespace=""} 1
+		sonic_vlan_members{namespace="",vlan="Vlan1000"} 2
+		sonic_vlan_members{namespace="",vlan="Vlan2000"} 0
 	`
 
 	if err := testutil.CollectAndCompare(vlanCollector, strings.NewReader(metadata+expected), "sonic_vlan_collector_success", "sonic_vlan_members"); err != nil {
@@ -844,8 +1155,8 @@ func TestVlanCollector(t *testing.T) {
 	`
 
 	memberExpected := `
-		sonic_vlan_member_info{member="Ethernet0",tagging_mode="untagged",vlan="Vlan1000"} 1
-		sonic_vlan_member_info{member="PortChannel1",tagging_mode="tagged",vlan="Vlan1000"} 1
+		sonic_vlan_member_info{member="Ethernet0",namespace="",tagging_mode="untagged",vlan="Vlan1000"} 1
+		sonic_vlan_member_info{member="PortChannel1",namespace="",tagging_mode="tagged",vlan="Vlan1000"} 1
 	`
 
 	if err := testutil.CollectAndCompare(vlanCollector, strings.NewReader(memberMetadata+memberExpected), "sonic_vlan_member_info"); err != nil {
@@ -859,7 +1170,7 @@ func TestVlanCollectorMetricFilter(t *testing.T) {
 
 	t.Run("wildcard disable removes vlan metric families", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_vlan_*")
-		vlanCollector := NewVlanCollector(logger, NewMetricFilter(logger))
+		vlanCollector := NewVlanCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, vlanCollector, "sonic_vlan_info", false)
 		assertMetricFamilyPresence(t, vlanCollector, "sonic_vlan_members", false)
 		assertMetricFamilyPresence(t, vlanCollector, "sonic_vlan_collector_success", false)
@@ -870,7 +1181,7 @@ func TestLagCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	lagCollector := NewLagCollector(logger, NewMetricFilter(logger))
+	lagCollector := NewLagCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(lagCollector)
 	if err != nil {
@@ -889,9 +1200,9 @@ func TestLagCollector(t *testing.T) {
 	`
 
 	expected := `
-		sonic_lag_collector_success 1
-		sonic_lag_members{lag="PortChannel1"} 2
-		sonic_lag_members{lag="PortChannel2"} 1
+		sonic_lag_collector_success{namespace=""} 1
+		sonic_lag_members{lag="PortChannel1",namespace=""} 2
+		sonic_lag_members{lag="PortChannel2",namespace=""} 1
 	`
 
 	if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(metadata+expected), "sonic_lag_collector_success", "sonic_lag_members"); err != nil {
@@ -904,9 +1215,9 @@ func TestLagCollector(t *testing.T) {
 	`
 
 	memberExpected := `
-		sonic_lag_member_status{lag="PortChannel1",member="Ethernet24"} 1
-		sonic_lag_member_status{lag="PortChannel1",member="Ethernet28"} 0
-		sonic_lag_member_status{lag="PortChannel2",member="Ethernet92"} 1
+		sonic_lag_member_status{lag="PortChannel1",member="Ethernet24",namespace=""} 1
+		sonic_lag_member_status{lag="PortChannel1",member="Ethernet28",namespace=""} 0
+		sonic_lag_member_status{lag="PortChannel2",member="Ethernet92",namespace=""} 1
 	`
 
 	if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(memberMetadata+memberExpected), "sonic_lag_member_status"); err != nil {
@@ -914,11 +1225,156 @@ func TestLagCollector(t *testing.T) {
 	}
 }
 
+func TestLagCollectorCounters(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(0).HSet("LAG_TABLE:PortChannel10", "oper_status", "up")
+	asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet0", "status", "enabled")
+	asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet4", "status", "enabled")
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001", "Ethernet4", "oid:0x1000000000002", "Ethernet8", "oid:0x1000000000003")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001",
+		"SAI_PORT_STAT_IF_IN_OCTETS", "1000",
+		"SAI_PORT_STAT_IF_IN_UCAST_PKTS", "10",
+		"SAI_PORT_STAT_IF_IN_MULTICAST_PKTS", "2",
+		"SAI_PORT_STAT_IF_IN_BROADCAST_PKTS", "1",
+		"SAI_PORT_STAT_IF_IN_ERRORS", "3",
+	)
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000002", "SAI_PORT_STAT_IF_IN_OCTETS", "500", "SAI_PORT_STAT_IF_IN_UCAST_PKTS", "5")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7000")
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_lag_*{namespace="asic0"}`)
+	config := loadLagCollectorConfig(logger)
+	config.refreshInterval = time.Hour
+	config.maxLags = 1
+	lagCollector := newLagCollector(logger, newTestMetricFilter(t, logger), redisClient, config, nil)
+	defer lagCollector.Stop()
+
+	metadata := `
+		# HELP sonic_lag_receive_bytes_total Number of bytes received on LAG member interfaces
+		# TYPE sonic_lag_receive_bytes_total counter
+		# HELP sonic_lag_receive_errors_total Number of receive errors on LAG member interfaces
+		# TYPE sonic_lag_receive_errors_total counter
+		# HELP sonic_lag_receive_packets_total Number of packets received on LAG member interfaces
+		# TYPE sonic_lag_receive_packets_total counter
+	`
+
+	expected := `
+		sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1500
+		sonic_lag_receive_errors_total{lag="PortChannel10",namespace="asic0"} 3
+		sonic_lag_receive_packets_total{lag="PortChannel10",namespace="asic0"} 18
+	`
+
+	if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(metadata+expected),
+		"sonic_lag_receive_bytes_total", "sonic_lag_receive_errors_total", "sonic_lag_receive_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Ethernet4 leaves and Ethernet8 joins, only Ethernet0 adds its delta
+	asic0.Del("LAG_MEMBER_TABLE:PortChannel10:Ethernet4")
+	asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet8", "status", "enabled")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "1100")
+	lagCollector.refreshMetrics()
+
+	bytesMetadata := `
+		# HELP sonic_lag_receive_bytes_total Number of bytes received on LAG member interfaces
+		# TYPE sonic_lag_receive_bytes_total counter
+	`
+
+	if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(bytesMetadata+`
+		sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1600
+	`), "sonic_lag_receive_bytes_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Cleared Ethernet0 counters add their new value
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "50")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7010")
+	lagCollector.refreshMetrics()
+
+	if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(bytesMetadata+`
+		sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1660
+	`), "sonic_lag_receive_bytes_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	steps := []struct {
+		name   string
+		update func()
+		want   string
+	}{
+		{
+			name: "member without counters is unchanged",
+			update: func() {
+				asic0.DB(2).Del("COUNTERS:oid:0x1000000000001")
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7020")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1670`,
+		},
+		{
+			name: "member counters are back",
+			update: func() {
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "80")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1700`,
+		},
+		{
+			name: "member leaves",
+			update: func() {
+				asic0.Del("LAG_MEMBER_TABLE:PortChannel10:Ethernet8")
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7100")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1700`,
+		},
+		{
+			name: "member rejoins",
+			update: func() {
+				asic0.DB(0).HSet("LAG_MEMBER_TABLE:PortChannel10:Ethernet8", "status", "enabled")
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7200")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1700`,
+		},
+		{
+			name: "rejoined member adds its delta",
+			update: func() {
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000003", "SAI_PORT_STAT_IF_IN_OCTETS", "7210")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1710`,
+		},
+		{
+			name: "LAG cut by LAG_MAX_LAGS",
+			update: func() {
+				asic0.DB(0).HSet("LAG_TABLE:PortChannel05", "oper_status", "up")
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "90")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel05",namespace="asic0"} 0`,
+		},
+		{
+			name: "LAG back under LAG_MAX_LAGS",
+			update: func() {
+				asic0.Del("LAG_TABLE:PortChannel05")
+				asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "100")
+			},
+			want: `sonic_lag_receive_bytes_total{lag="PortChannel10",namespace="asic0"} 1730`,
+		},
+	}
+
+	for _, step := range steps {
+		step.update()
+		lagCollector.refreshMetrics()
+
+		if err := testutil.CollectAndCompare(lagCollector, strings.NewReader(bytesMetadata+step.want+"\n"), "sonic_lag_receive_bytes_total"); err != nil {
+			t.Errorf("%s: unexpected collecting result:\n%s", step.name, err)
+		}
+	}
+}
+
 func TestFdbCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	fdbCollector := NewFdbCollector(logger, NewMetricFilter(logger))
+	fdbCollector := NewFdbCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(fdbCollector)
 	if err != nil {
@@ -939,9 +1395,9 @@ func TestFdbCollector(t *testing.T) {
 	`
 
 	expected := `
-		sonic_fdb_collector_success 1
-		sonic_fdb_entries 4
-		sonic_fdb_entries_unknown_vlan 1
+		sonic_fdb_collector_success{namespace=""} 1
+		sonic_fdb_entries{namespace=""} 4
+		sonic_fdb_entries_unknown_vlan{namespace=""} 1
 	`
 
 	if err := testutil.CollectAndCompare(fdbCollector, strings.NewReader(metadata+expected), "sonic_fdb_collector_success", "sonic_fdb_entries", "sonic_fdb_entries_unknown_vlan"); err != nil {
@@ -954,8 +1410,8 @@ func TestFdbCollector(t *testing.T) {
 	`
 
 	portExpected := `
-		sonic_fdb_entries_by_port{port="Ethernet0"} 2
-		sonic_fdb_entries_by_port{port="Ethernet39"} 2
+		sonic_fdb_entries_by_port{namespace="",port="Ethernet0"} 2
+		sonic_fdb_entries_by_port{namespace="",port="Ethernet39"} 2
 	`
 
 	if err := testutil.CollectAndCompare(fdbCollector, strings.NewReader(portMetadata+portExpected), "sonic_fdb_entries_by_port"); err != nil {
@@ -968,9 +1424,9 @@ func TestFdbCollector(t *testing.T) {
 	`
 
 	vlanExpected := `
-		sonic_fdb_entries_by_vlan{vlan="1000"} 2
-		sonic_fdb_entries_by_vlan{vlan="2000"} 1
-		sonic_fdb_entries_by_vlan{vlan="unknown"} 1
+		sonic_fdb_entries_by_vlan{namespace="",vlan="1000"} 2
+		sonic_fdb_entries_by_vlan{namespace="",vlan="2000"} 1
+		sonic_fdb_entries_by_vlan{namespace="",vlan="unknown"} 1
 	`
 
 	if err := testutil.CollectAndCompare(fdbCollector, strings.NewReader(vlanMetadata+vlanExpected), "sonic_fdb_entries_by_vlan"); err != nil {
@@ -983,8 +1439,8 @@ func TestFdbCollector(t *testing.T) {
 	`
 
 	typeExpected := `
-		sonic_fdb_entries_by_type{entry_type="dynamic"} 3
-		sonic_fdb_entries_by_type{entry_type="static"} 1
+		sonic_fdb_entries_by_type{entry_type="dynamic",namespace=""} 3
+		sonic_fdb_entries_by_type{entry_type="static",namespace=""} 1
 	`
 
 	if err := testutil.CollectAndCompare(fdbCollector, strings.NewReader(typeMetadata+typeExpected), "sonic_fdb_entries_by_type"); err != nil {
@@ -1014,7 +1470,7 @@ func TestFdbCollectorMetricFilter(t *testing.T) {
 
 	t.Run("exact disable removes only fdb entries by port", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_fdb_entries_by_port")
-		fdbCollector := NewFdbCollector(logger, NewMetricFilter(logger))
+		fdbCollector := NewFdbCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, fdbCollector, "sonic_fdb_entries_by_port", false)
 		assertMetricFamilyPresence(t, fdbCollector, "sonic_fdb_entries", true)
 	})
@@ -1024,7 +1480,7 @@ func TestSystemCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	systemCollector := NewSystemCollector(logger, NewMetricFilter(logger))
+	systemCollector := NewSystemCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(systemCollector)
 	if err != nil {
@@ -1084,7 +1540,7 @@ func TestSystemCollectorMetricFilter(t *testing.T) {
 
 	t.Run("exact disable removes uptime metric family only", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_system_uptime_seconds")
-		systemCollector := NewSystemCollector(logger, NewMetricFilter(logger))
+		systemCollector := NewSystemCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, systemCollector, "sonic_system_uptime_seconds", false)
 		assertMetricFamilyPresence(t, systemCollector, "sonic_system_identity_info", true)
 	})
@@ -1094,7 +1550,7 @@ func TestDockerCollector(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	dockerCollector := NewDockerCollector(logger, NewMetricFilter(logger))
+	dockerCollector := NewDockerCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	problems, err := testutil.CollectAndLint(dockerCollector)
 	if err != nil {
@@ -1162,7 +1618,7 @@ func TestDockerCollectorMaxContainers(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	dockerCollector := NewDockerCollector(logger, NewMetricFilter(logger))
+	dockerCollector := NewDockerCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 
 	metadata := `
 		# HELP sonic_docker_containers Number of containers with DOCKER_STATS entries
@@ -1187,7 +1643,7 @@ func TestDockerCollectorMetricFilter(t *testing.T) {
 
 	t.Run("wildcard disable removes docker container metric families", func(t *testing.T) {
 		t.Setenv("SONIC_DISABLED_METRICS", "sonic_docker_container_*")
-		dockerCollector := NewDockerCollector(logger, NewMetricFilter(logger))
+		dockerCollector := NewDockerCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
 		assertMetricFamilyPresence(t, dockerCollector, "sonic_docker_container_info", false)
 		assertMetricFamilyPresence(t, dockerCollector, "sonic_docker_container_cpu_percent", false)
 		assertMetricFamilyPresence(t, dockerCollector, "sonic_docker_containers", true)
@@ -1209,7 +1665,7 @@ func TestFrrCollectorDisabledByDefault(t *testing.T) {
 	promslogConfig := &promslog.Config{}
 	logger := promslog.New(promslogConfig)
 
-	frrCollector := NewFrrCollector(logger)
+	frrCollector := NewFrrCollector(logger, newTestMetricFilter(t, logger))
 
 	if frrCollector.IsEnabled() {
 		t.Fatal("expected FRR collector to be disabled by default")
@@ -1288,3 +1744,661 @@ func TestBuildFrrCollectorArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestRedisPoolCollector(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisPoolCollector := NewRedisPoolCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	problems, err := testutil.CollectAndLint(redisPoolCollector)
+	if err != nil {
+		t.Error("metric lint completed with errors")
+	}
+
+	for _, problem := range problems {
+		t.Errorf("metric %v has a problem: %v", problem.Metric, problem.Text)
+	}
+
+	// Fixtures were loaded through the shared client, so its COUNTERS_DB pool holds an open connection.
+	connectionsFamily := getMetricFamily(t, redisPoolCollector, "sonic_exporter_redis_pool_connections")
+	if connectionsFamily == nil {
+		t.Fatalf("expected sonic_exporter_redis_pool_connections metric family")
+	}
+	countersDbConnections := 0.0
+	for _, metric := range connectionsFamily.Metric {
+		for _, label := range metric.Label {
+			if label.GetName() == "database" && label.GetValue() == "COUNTERS_DB" {
+				countersDbConnections = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	if countersDbConnections < 1 {
+		t.Errorf("COUNTERS_DB pool connections = %v, want at least 1", countersDbConnections)
+	}
+	assertMetricFamilyPresence(t, redisPoolCollector, "sonic_exporter_redis_pool_hits_total", true)
+
+	t.Run("wildcard disable removes pool metric families", func(t *testing.T) {
+		t.Setenv("SONIC_DISABLED_METRICS", "sonic_exporter_redis_pool_*")
+		redisPoolCollector := NewRedisPoolCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, redisPoolCollector, "sonic_exporter_redis_pool_connections", false)
+		assertMetricFamilyPresence(t, redisPoolCollector, "sonic_exporter_redis_pool_hits_total", false)
+	})
+}
+
+func newNamespacedTestRedisClient(t *testing.T) (redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	asic0 := miniredis.RunT(t)
+	dir := t.TempDir()
+
+	databaseConfig := fmt.Sprintf(`{
+		"INSTANCES": {"redis": {"hostname": %q, "port": %s}},
+		"DATABASES": {
+			"APPL_DB": {"id": 0, "instance": "redis"},
+			"ASIC_DB": {"id": 1, "instance": "redis"},
+			"COUNTERS_DB": {"id": 2, "instance": "redis"},
+			"CONFIG_DB": {"id": 4, "instance": "redis"},
+			"STATE_DB": {"id": 6, "instance": "redis"}
+		}
+	}`, asic0.Host(), asic0.Port())
+	if err := os.WriteFile(filepath.Join(dir, "database_config0.json"), []byte(databaseConfig), 0o644); err != nil {
+		t.Fatalf("write database config: %v", err)
+	}
+
+	globalFile := filepath.Join(dir, "database_global.json")
+	if err := os.WriteFile(globalFile, []byte(`{"INCLUDES": [{"namespace": "asic0", "include": "database_config0.json"}]}`), 0o644); err != nil {
+		t.Fatalf("write database global file: %v", err)
+	}
+
+	// Host namespace keeps using the shared fixtures on REDIS_ADDRESS.
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", "")
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", globalFile)
+
+	redisClient, err := redis.NewClient()
+	if err != nil {
+		t.Fatalf("redis.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { redisClient.Close() })
+
+	return redisClient, asic0
+}
+
+func TestCollectorsScrapeEveryNamespace(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("CRM:STATS", "crm_stats_ipv4_route_used", "10", "crm_stats_ipv4_route_available", "90")
+
+	crmCollector := NewCrmCollector(logger, newTestMetricFilter(t, logger), redisClient)
+	usedFamily := getMetricFamily(t, crmCollector, "sonic_crm_resource_used")
+	if !metricWithLabelsExists(usedFamily, map[string]string{"resource": "ipv4_route", "namespace": "asic0"}, 10) {
+		t.Errorf("expected sonic_crm_resource_used for asic0 namespace")
+	}
+
+	fdbCollector := NewFdbCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	metadata := `
+		# HELP sonic_fdb_collector_success Whether FDB collector succeeded
+		# TYPE sonic_fdb_collector_success gauge
+		# HELP sonic_fdb_entries Number of FDB entries
+		# TYPE sonic_fdb_entries gauge
+	`
+
+	expected := `
+		sonic_fdb_collector_success{namespace=""} 1
+		sonic_fdb_collector_success{namespace="asic0"} 1
+		sonic_fdb_entries{namespace=""} 4
+		sonic_fdb_entries{namespace="asic0"} 0
+	`
+
+	if err := testutil.CollectAndCompare(fdbCollector, strings.NewReader(metadata+expected), "sonic_fdb_collector_success", "sonic_fdb_entries"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	t.Run("failing namespace does not hide the others", func(t *testing.T) {
+		asic0.Close()
+
+		crmCollector := NewCrmCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+		metadata := `
+			# HELP sonic_crm_collector_success Whether crm collector succeeded
+			# TYPE sonic_crm_collector_success gauge
+		`
+
+		expected := `
+			sonic_crm_collector_success{namespace=""} 1
+			sonic_crm_collector_success{namespace="asic0"} 0
+		`
+
+		if err := testutil.CollectAndCompare(crmCollector, strings.NewReader(metadata+expected), "sonic_crm_collector_success"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+
+		usedFamily := getMetricFamily(t, crmCollector, "sonic_crm_resource_used")
+		if len(usedFamily.GetMetric()) == 0 {
+			t.Errorf("expected host namespace crm metrics while asic0 is down")
+		}
+	})
+}
+
+func TestInterfaceCollectorSkipsBrokenEntries(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001", "Ethernet4", "oid:0x1000000000002")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "100", "SAI_PORT_STAT_ETHER_IN_PKTS_9217_TO_16383_OCTETS", "N/A")
+	// A counters key of the wrong type fails the read of Ethernet4 only
+	asic0.DB(2).Set("COUNTERS:oid:0x1000000000002", "broken")
+	asic0.DB(4).HSet("PORT|Ethernet0", "mtu", "unknown", "speed", "100000")
+
+	interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	successFamily := getMetricFamily(t, interfaceCollector, "sonic_interface_collector_success")
+	if !metricWithLabelsExists(successFamily, map[string]string{"namespace": "asic0"}, 1) {
+		t.Errorf("expected sonic_interface_collector_success 1 for asic0 namespace")
+	}
+
+	speedFamily := getMetricFamily(t, interfaceCollector, "sonic_interface_speed_bytes")
+	if !metricWithLabelsExists(speedFamily, map[string]string{"device": "Ethernet0", "namespace": "asic0"}, 100000*1000*1000/8) {
+		t.Errorf("expected sonic_interface_speed_bytes for Ethernet0 in asic0 namespace")
+	}
+
+	mtuFamily := getMetricFamily(t, interfaceCollector, "sonic_interface_mtu_bytes")
+	if metricWithLabelsExists(mtuFamily, map[string]string{"device": "Ethernet0", "namespace": "asic0"}, 0) {
+		t.Errorf("expected no sonic_interface_mtu_bytes for unparsable Ethernet0 mtu")
+	}
+
+	metadata := `
+		# HELP sonic_interface_counter_parse_errors_total Number of interface counter values that could not be parsed
+		# TYPE sonic_interface_counter_parse_errors_total counter
+		# HELP sonic_interface_entries_skipped Number of interface entries skipped during latest refresh
+		# TYPE sonic_interface_entries_skipped gauge
+	`
+
+	expected := `
+		sonic_interface_counter_parse_errors_total{counter="SAI_PORT_STAT_ETHER_IN_PKTS_9217_TO_16383_OCTETS"} 1
+		sonic_interface_counter_parse_errors_total{counter="mtu"} 1
+		sonic_interface_entries_skipped 1
+	`
+
+	if err := testutil.CollectAndCompare(interfaceCollector, strings.NewReader(metadata+expected), "sonic_interface_counter_parse_errors_total", "sonic_interface_entries_skipped"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestInterfaceCollectorDropsPartiallyReadPort(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001", "Ethernet4", "oid:0x1000000000002")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_OCTETS", "100")
+	// The config and status of Ethernet4 are read before its counters fail
+	asic0.DB(2).Set("COUNTERS:oid:0x1000000000002", "broken")
+	asic0.DB(4).HSet("PORT|Ethernet0", "mtu", "9100", "speed", "100000")
+	asic0.DB(4).HSet("PORT|Ethernet4", "mtu", "9100", "speed", "100000", "admin_status", "up")
+	asic0.DB(0).HSet("PORT_TABLE:Ethernet4", "oper_status", "up")
+
+	interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(interfaceCollector)
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, metricFamily := range metricFamilies {
+		for _, metric := range metricFamily.Metric {
+			for _, label := range metric.Label {
+				if label.GetName() == "device" && label.GetValue() == "Ethernet4" {
+					t.Errorf("expected no %s series for partially read Ethernet4", metricFamily.GetName())
+				}
+			}
+		}
+	}
+
+	mtuFamily := getMetricFamily(t, interfaceCollector, "sonic_interface_mtu_bytes")
+	if !metricWithLabelsExists(mtuFamily, map[string]string{"device": "Ethernet0", "namespace": "asic0"}, 9100) {
+		t.Errorf("expected sonic_interface_mtu_bytes for Ethernet0 in asic0 namespace")
+	}
+
+	skippedFamily := getMetricFamily(t, interfaceCollector, "sonic_interface_entries_skipped")
+	if !metricWithLabelsExists(skippedFamily, map[string]string{}, 1) {
+		t.Errorf("expected sonic_interface_entries_skipped 1")
+	}
+}
+
+func TestInterfaceCollectorSaiStats(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001",
+		"SAI_PORT_STAT_ECN_MARKED_PACKETS", "5",
+		"SAI_PORT_STAT_WRED_DROPPED_BYTES", "700",
+		"SAI_PORT_STAT_WRED_DROPPED_PACKETS", "7",
+	)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+		assertMetricFamilyPresence(t, interfaceCollector, "sonic_interface_sai_stat_total", false)
+	})
+
+	t.Run("exports selected stats up to the limit", func(t *testing.T) {
+		t.Setenv("INTERFACE_SAI_STATS", "SAI_PORT_STAT_ECN_MARKED_PACKETS,SAI_PORT_STAT_WRED_*")
+		t.Setenv("INTERFACE_MAX_SAI_STATS", "2")
+
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+		metadata := `
+			# HELP sonic_interface_entries_truncated Whether SAI stat passthrough hit the max stats limit (1=yes, 0=no)
+			# TYPE sonic_interface_entries_truncated gauge
+			# HELP sonic_interface_sai_stat_total Value of a SAI port stat selected by INTERFACE_SAI_STATS
+			# TYPE sonic_interface_sai_stat_total counter
+		`
+
+		expected := `
+			sonic_interface_entries_truncated 1
+			sonic_interface_sai_stat_total{device="Ethernet0",namespace="asic0",stat="SAI_PORT_STAT_ECN_MARKED_PACKETS"} 5
+			sonic_interface_sai_stat_total{device="Ethernet0",namespace="asic0",stat="SAI_PORT_STAT_WRED_DROPPED_BYTES"} 700
+		`
+
+		if err := testutil.CollectAndCompare(interfaceCollector, strings.NewReader(metadata+expected), "sonic_interface_sai_stat_total", "sonic_interface_entries_truncated"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+	})
+}
+
+func TestInterfaceCollectorFec(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001")
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001",
+		"SAI_PORT_STAT_IF_IN_FEC_CORRECTABLE_FRAMES", "40",
+		"SAI_PORT_STAT_IF_IN_FEC_NOT_CORRECTABLE_FRAMES", "2",
+		"SAI_PORT_STAT_IF_IN_FEC_SYMBOL_ERRORS", "50",
+		"SAI_PORT_STAT_IF_IN_FEC_CORRECTED_BITS", "1000",
+		"SAI_PORT_STAT_IF_IN_FEC_CODEWORD_ERRORS_S0", "900",
+		"SAI_PORT_STAT_IF_IN_FEC_CODEWORD_ERRORS_S1", "30",
+	)
+	asic0.DB(4).HSet("PORT|Ethernet0", "speed", "100000")
+
+	interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	metadata := `
+		# HELP sonic_interface_fec_codeword_errors_total Number of received FEC codewords by number of symbol errors in bin
+		# TYPE sonic_interface_fec_codeword_errors_total counter
+		# HELP sonic_interface_fec_corrected_bits_total Number of bits corrected by FEC
+		# TYPE sonic_interface_fec_corrected_bits_total counter
+		# HELP sonic_interface_fec_frames_total Number of received FEC frames with errors by type: correctable, uncorrectable
+		# TYPE sonic_interface_fec_frames_total counter
+		# HELP sonic_interface_fec_symbol_errors_total Number of received FEC symbol errors
+		# TYPE sonic_interface_fec_symbol_errors_total counter
+	`
+
+	expected := `
+		sonic_interface_fec_codeword_errors_total{bin="0",device="Ethernet0",namespace="asic0"} 900
+		sonic_interface_fec_codeword_errors_total{bin="1",device="Ethernet0",namespace="asic0"} 30
+		sonic_interface_fec_corrected_bits_total{device="Ethernet0",namespace="asic0"} 1000
+		sonic_interface_fec_frames_total{device="Ethernet0",namespace="asic0",type="correctable"} 40
+		sonic_interface_fec_frames_total{device="Ethernet0",namespace="asic0",type="uncorrectable"} 2
+		sonic_interface_fec_symbol_errors_total{device="Ethernet0",namespace="asic0"} 50
+	`
+
+	if err := testutil.CollectAndCompare(interfaceCollector, strings.NewReader(metadata+expected),
+		"sonic_interface_fec_codeword_errors_total", "sonic_interface_fec_corrected_bits_total", "sonic_interface_fec_frames_total", "sonic_interface_fec_symbol_errors_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// The first refresh has no previous sample to compute BER from
+	assertMetricFamilyPresence(t, interfaceCollector, "sonic_interface_fec_pre_ber", false)
+
+	// 100G over 10 seconds is 1e12 bits on the line
+	previous := interfaceCollector.fecSamples["asic0"]["Ethernet0"]
+	previous.time = previous.time.Add(-10 * time.Second)
+	interfaceCollector.fecSamples["asic0"]["Ethernet0"] = previous
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001",
+		"SAI_PORT_STAT_IF_IN_FEC_CORRECTED_BITS", "1000001000",
+		"SAI_PORT_STAT_IF_IN_FEC_NOT_CORRECTABLE_FRAMES", "3",
+	)
+	interfaceCollector.refreshMetrics()
+
+	assertBer := func(bers map[string]float64) {
+		t.Helper()
+
+		for metricName, want := range bers {
+			family := getMetricFamily(t, interfaceCollector, metricName)
+			if family == nil || len(family.GetMetric()) != 1 {
+				t.Fatalf("expected one %s series, got %v", metricName, family)
+			}
+			if got := family.GetMetric()[0].GetGauge().GetValue(); got < want*0.99 || got > want*1.01 {
+				t.Errorf("%s = %g, want about %g", metricName, got, want)
+			}
+		}
+	}
+	assertBer(map[string]float64{
+		"sonic_interface_fec_pre_ber":  1e-3,
+		"sonic_interface_fec_post_ber": 5280e-12,
+	})
+
+	// The BER of SONiC port rates wins, a missing field is still computed
+	asic0.DB(2).HSet("RATES:oid:0x1000000000001", "FEC_PRE_BER", "2.5e-08")
+	previous = interfaceCollector.fecSamples["asic0"]["Ethernet0"]
+	previous.time = previous.time.Add(-10 * time.Second)
+	interfaceCollector.fecSamples["asic0"]["Ethernet0"] = previous
+	asic0.DB(2).HSet("COUNTERS:oid:0x1000000000001", "SAI_PORT_STAT_IF_IN_FEC_NOT_CORRECTABLE_FRAMES", "4")
+	interfaceCollector.refreshMetrics()
+
+	assertBer(map[string]float64{
+		"sonic_interface_fec_pre_ber":  2.5e-8,
+		"sonic_interface_fec_post_ber": 5280e-12,
+	})
+}
+
+func TestInterfaceCollectorRates(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	redisClient, asic0 := newNamespacedTestRedisClient(t)
+	asic0.DB(2).HSet("COUNTERS_PORT_NAME_MAP", "Ethernet0", "oid:0x1000000000001", "Ethernet4", "oid:0x1000000000002")
+	asic0.DB(2).HSet("RATES:oid:0x1000000000001", "RX_BPS", "1250000000", "TX_BPS", "125000000", "RX_PPS", "1000000", "TX_PPS", "20000")
+	asic0.DB(2).HSet("RATES:oid:0x1000000000002", "RX_BPS", "0", "RX_UTIL", "5.5")
+	asic0.DB(2).HSet("RATES:PORT", "PORT_ALPHA", "0.18", "PORT_SMOOTH_INTERVAL", "10")
+	asic0.DB(4).HSet("PORT|Ethernet0", "speed", "100000")
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_interface_*{namespace="asic0"}`)
+	interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), redisClient)
+
+	metadata := `
+		# HELP sonic_interface_rate_smoothing_alpha Smoothing factor SONiC port rates use
+		# TYPE sonic_interface_rate_smoothing_alpha gauge
+		# HELP sonic_interface_rate_smoothing_interval_seconds Smoothing interval SONiC port rates use
+		# TYPE sonic_interface_rate_smoothing_interval_seconds gauge
+		# HELP sonic_interface_receive_bits_per_second Smoothed receive rate computed by SONiC port rates
+		# TYPE sonic_interface_receive_bits_per_second gauge
+		# HELP sonic_interface_receive_packets_per_second Smoothed receive packet rate computed by SONiC port rates
+		# TYPE sonic_interface_receive_packets_per_second gauge
+		# HELP sonic_interface_transmit_bits_per_second Smoothed transmit rate computed by SONiC port rates
+		# TYPE sonic_interface_transmit_bits_per_second gauge
+		# HELP sonic_interface_transmit_packets_per_second Smoothed transmit packet rate computed by SONiC port rates
+		# TYPE sonic_interface_transmit_packets_per_second gauge
+		# HELP sonic_interface_utilization_ratio Port utilization by direction: rx, tx
+		# TYPE sonic_interface_utilization_ratio gauge
+	`
+
+	expected := `
+		sonic_interface_rate_smoothing_alpha{namespace="asic0"} 0.18
+		sonic_interface_rate_smoothing_interval_seconds{namespace="asic0"} 10
+		sonic_interface_receive_bits_per_second{device="Ethernet0",namespace="asic0"} 1e+10
+		sonic_interface_receive_bits_per_second{device="Ethernet4",namespace="asic0"} 0
+		sonic_interface_receive_packets_per_second{device="Ethernet0",namespace="asic0"} 1e+06
+		sonic_interface_transmit_bits_per_second{device="Ethernet0",namespace="asic0"} 1e+09
+		sonic_interface_transmit_packets_per_second{device="Ethernet0",namespace="asic0"} 20000
+		sonic_interface_utilization_ratio{device="Ethernet0",direction="rx",namespace="asic0"} 0.1
+		sonic_interface_utilization_ratio{device="Ethernet0",direction="tx",namespace="asic0"} 0.01
+		sonic_interface_utilization_ratio{device="Ethernet4",direction="rx",namespace="asic0"} 0.055
+	`
+
+	if err := testutil.CollectAndCompare(interfaceCollector, strings.NewReader(metadata+expected),
+		"sonic_interface_rate_smoothing_alpha", "sonic_interface_rate_smoothing_interval_seconds",
+		"sonic_interface_receive_bits_per_second", "sonic_interface_receive_packets_per_second",
+		"sonic_interface_transmit_bits_per_second", "sonic_interface_transmit_packets_per_second",
+		"sonic_interface_utilization_ratio"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestInterfaceCollectorPfc(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+		assertMetricFamilyPresence(t, interfaceCollector, "sonic_interface_pfc_frames_total", false)
+	})
+
+	t.Run("exports per priority counters", func(t *testing.T) {
+		t.Setenv("INTERFACE_PFC_ENABLED", "true")
+		t.Setenv("SONIC_METRIC_LABEL_RULES", `keep sonic_interface_pfc_*{priority="3"}`)
+
+		interfaceCollector := NewInterfaceCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+		metadata := `
+			# HELP sonic_interface_pfc_frames_total Number of PFC frames per priority and direction: rx, tx
+			# TYPE sonic_interface_pfc_frames_total counter
+			# HELP sonic_interface_pfc_pause_duration_seconds_total Time paused by PFC per priority and direction: rx, tx
+			# TYPE sonic_interface_pfc_pause_duration_seconds_total counter
+		`
+
+		expected := `
+			sonic_interface_pfc_frames_total{device="Ethernet0",direction="rx",namespace="",priority="3"} 1200
+			sonic_interface_pfc_frames_total{device="Ethernet0",direction="tx",namespace="",priority="3"} 800
+			sonic_interface_pfc_pause_duration_seconds_total{device="Ethernet0",direction="rx",namespace="",priority="3"} 0.045
+			sonic_interface_pfc_pause_duration_seconds_total{device="Ethernet0",direction="tx",namespace="",priority="3"} 0.03
+		`
+
+		if err := testutil.CollectAndCompare(interfaceCollector, strings.NewReader(metadata+expected), "sonic_interface_pfc_frames_total", "sonic_interface_pfc_pause_duration_seconds_total"); err != nil {
+			t.Errorf("unexpected collecting result:\n%s", err)
+		}
+	})
+}
+
+func TestPfcwdCollector(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Setenv("PFCWD_ENABLED", "true")
+	pfcwdCollector := NewPfcwdCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	problems, err := testutil.CollectAndLint(pfcwdCollector)
+	if err != nil {
+		t.Error("metric lint completed with errors")
+	}
+
+	for _, problem := range problems {
+		t.Errorf("metric %v has a problem: %v", problem.Metric, problem.Text)
+	}
+
+	metadata := `
+		# HELP sonic_pfcwd_collector_success Whether PFC watchdog collector succeeded
+		# TYPE sonic_pfcwd_collector_success gauge
+		# HELP sonic_pfcwd_detection_time_seconds Configured PFC storm detection time of a port
+		# TYPE sonic_pfcwd_detection_time_seconds gauge
+		# HELP sonic_pfcwd_dropped_packets_total Number of packets dropped by PFC watchdog on a queue by direction: rx, tx
+		# TYPE sonic_pfcwd_dropped_packets_total counter
+		# HELP sonic_pfcwd_entries_skipped Number of PFC watchdog entries skipped during latest refresh
+		# TYPE sonic_pfcwd_entries_skipped gauge
+		# HELP sonic_pfcwd_restoration_time_seconds Configured PFC storm restoration time of a port
+		# TYPE sonic_pfcwd_restoration_time_seconds gauge
+		# HELP sonic_pfcwd_storm_active Whether a PFC storm is active on a queue (1=stormed, 0=operational)
+		# TYPE sonic_pfcwd_storm_active gauge
+		# HELP sonic_pfcwd_storm_detected_total Number of PFC storms detected on a queue
+		# TYPE sonic_pfcwd_storm_detected_total counter
+		# HELP sonic_pfcwd_storm_restored_total Number of PFC storms restored on a queue
+		# TYPE sonic_pfcwd_storm_restored_total counter
+	`
+
+	// Ethernet39 has no restoration time, the detection time of Ethernet40 does not parse
+	expected := `
+		sonic_pfcwd_collector_success{namespace=""} 1
+		sonic_pfcwd_detection_time_seconds{device="Ethernet0",namespace=""} 0.2
+		sonic_pfcwd_detection_time_seconds{device="Ethernet39",namespace=""} 0.4
+		sonic_pfcwd_dropped_packets_total{device="Ethernet0",direction="rx",namespace="",queue="0"} 0
+		sonic_pfcwd_dropped_packets_total{device="Ethernet0",direction="rx",namespace="",queue="1"} 7
+		sonic_pfcwd_dropped_packets_total{device="Ethernet0",direction="tx",namespace="",queue="0"} 0
+		sonic_pfcwd_dropped_packets_total{device="Ethernet0",direction="tx",namespace="",queue="1"} 95
+		sonic_pfcwd_entries_skipped 1
+		sonic_pfcwd_restoration_time_seconds{device="Ethernet0",namespace=""} 0.4
+		sonic_pfcwd_restoration_time_seconds{device="Ethernet40",namespace=""} 0.4
+		sonic_pfcwd_storm_active{device="Ethernet0",namespace="",queue="0"} 0
+		sonic_pfcwd_storm_active{device="Ethernet0",namespace="",queue="1"} 1
+		sonic_pfcwd_storm_detected_total{device="Ethernet0",namespace="",queue="0"} 0
+		sonic_pfcwd_storm_detected_total{device="Ethernet0",namespace="",queue="1"} 3
+		sonic_pfcwd_storm_restored_total{device="Ethernet0",namespace="",queue="0"} 0
+		sonic_pfcwd_storm_restored_total{device="Ethernet0",namespace="",queue="1"} 2
+	`
+
+	if err := testutil.CollectAndCompare(pfcwdCollector, strings.NewReader(metadata+expected),
+		"sonic_pfcwd_collector_success", "sonic_pfcwd_detection_time_seconds", "sonic_pfcwd_dropped_packets_total", "sonic_pfcwd_entries_skipped",
+		"sonic_pfcwd_restoration_time_seconds", "sonic_pfcwd_storm_active", "sonic_pfcwd_storm_detected_total", "sonic_pfcwd_storm_restored_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestBufferPoolCollector(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Setenv("BUFFER_POOL_ENABLED", "true")
+	bufferPoolCollector := NewBufferPoolCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	problems, err := testutil.CollectAndLint(bufferPoolCollector)
+	if err != nil {
+		t.Error("metric lint completed with errors")
+	}
+
+	for _, problem := range problems {
+		t.Errorf("metric %v has a problem: %v", problem.Metric, problem.Text)
+	}
+
+	metadata := `
+		# HELP sonic_buffer_pool_collector_success Whether buffer pool collector succeeded
+		# TYPE sonic_buffer_pool_collector_success gauge
+		# HELP sonic_buffer_pool_entries_skipped Number of buffer pools skipped during latest refresh
+		# TYPE sonic_buffer_pool_entries_skipped gauge
+		# HELP sonic_buffer_pool_headroom_size_bytes Size of the shared headroom (xoff) of a buffer pool
+		# TYPE sonic_buffer_pool_headroom_size_bytes gauge
+		# HELP sonic_buffer_pool_headroom_watermark_bytes Buffer pool shared headroom watermark by watermark type: user, persistent, periodic
+		# TYPE sonic_buffer_pool_headroom_watermark_bytes gauge
+		# HELP sonic_buffer_pool_info Non-numeric data about a buffer pool, value is always 1
+		# TYPE sonic_buffer_pool_info gauge
+		# HELP sonic_buffer_pool_occupancy_bytes Current occupancy of a buffer pool
+		# TYPE sonic_buffer_pool_occupancy_bytes gauge
+		# HELP sonic_buffer_pool_size_bytes Size of a buffer pool
+		# TYPE sonic_buffer_pool_size_bytes gauge
+		# HELP sonic_buffer_pool_utilization_ratio Buffer pool watermark divided by buffer pool size
+		# TYPE sonic_buffer_pool_utilization_ratio gauge
+		# HELP sonic_buffer_pool_watermark_bytes Buffer pool watermark by watermark type: user, persistent, periodic
+		# TYPE sonic_buffer_pool_watermark_bytes gauge
+	`
+
+	expected := `
+		sonic_buffer_pool_collector_success{namespace=""} 1
+		sonic_buffer_pool_entries_skipped 0
+		sonic_buffer_pool_headroom_size_bytes{namespace="",pool="ingress_lossless_pool"} 4.194304e+06
+		sonic_buffer_pool_headroom_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="periodic"} 0
+		sonic_buffer_pool_headroom_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="persistent"} 1.048576e+06
+		sonic_buffer_pool_headroom_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="user"} 524288
+		sonic_buffer_pool_info{direction="egress",mode="dynamic",namespace="",pool="egress_lossy_pool"} 1
+		sonic_buffer_pool_info{direction="ingress",mode="dynamic",namespace="",pool="ingress_lossless_pool"} 1
+		sonic_buffer_pool_occupancy_bytes{namespace="",pool="ingress_lossless_pool"} 1.048576e+06
+		sonic_buffer_pool_size_bytes{namespace="",pool="egress_lossy_pool"} 9.8304e+06
+		sonic_buffer_pool_size_bytes{namespace="",pool="ingress_lossless_pool"} 1.31072e+07
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="egress_lossy_pool",type="periodic"} 0.125
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="egress_lossy_pool",type="persistent"} 0.5
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="egress_lossy_pool",type="user"} 0.25
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="ingress_lossless_pool",type="periodic"} 0.125
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="ingress_lossless_pool",type="persistent"} 0.5
+		sonic_buffer_pool_utilization_ratio{namespace="",pool="ingress_lossless_pool",type="user"} 0.25
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="egress_lossy_pool",type="periodic"} 1.2288e+06
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="egress_lossy_pool",type="persistent"} 4.9152e+06
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="egress_lossy_pool",type="user"} 2.4576e+06
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="periodic"} 1.6384e+06
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="persistent"} 6.5536e+06
+		sonic_buffer_pool_watermark_bytes{namespace="",pool="ingress_lossless_pool",type="user"} 3.2768e+06
+	`
+
+	if err := testutil.CollectAndCompare(bufferPoolCollector, strings.NewReader(metadata+expected),
+		"sonic_buffer_pool_collector_success", "sonic_buffer_pool_entries_skipped",
+		"sonic_buffer_pool_headroom_size_bytes", "sonic_buffer_pool_headroom_watermark_bytes",
+		"sonic_buffer_pool_info", "sonic_buffer_pool_occupancy_bytes", "sonic_buffer_pool_size_bytes",
+		"sonic_buffer_pool_utilization_ratio", "sonic_buffer_pool_watermark_bytes"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestRifCollector(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Setenv("RIF_ENABLED", "true")
+	rifCollector := NewRifCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	problems, err := testutil.CollectAndLint(rifCollector)
+	if err != nil {
+		t.Error("metric lint completed with errors")
+	}
+
+	for _, problem := range problems {
+		t.Errorf("metric %v has a problem: %v", problem.Metric, problem.Text)
+	}
+
+	metadata := `
+		# HELP sonic_rif_bytes_total Number of bytes on a router interface by direction: rx, tx
+		# TYPE sonic_rif_bytes_total counter
+		# HELP sonic_rif_entries_skipped Number of router interfaces skipped during latest refresh
+		# TYPE sonic_rif_entries_skipped gauge
+		# HELP sonic_rif_entries_truncated Whether RIF collection hit max interfaces limit (1=yes, 0=no)
+		# TYPE sonic_rif_entries_truncated gauge
+		# HELP sonic_rif_error_packets_total Number of error packets on a router interface by direction: rx, tx
+		# TYPE sonic_rif_error_packets_total counter
+	`
+
+	expected := `
+		sonic_rif_bytes_total{direction="rx",namespace="",rif="Ethernet0",rif_type="port"} 123456
+		sonic_rif_bytes_total{direction="rx",namespace="",rif="PortChannel0001",rif_type="unknown"} 4096
+		sonic_rif_bytes_total{direction="rx",namespace="",rif="Vlan1000",rif_type="vlan"} 98765
+		sonic_rif_bytes_total{direction="tx",namespace="",rif="Ethernet0",rif_type="port"} 654321
+		sonic_rif_bytes_total{direction="tx",namespace="",rif="PortChannel0001",rif_type="unknown"} 8192
+		sonic_rif_bytes_total{direction="tx",namespace="",rif="Vlan1000",rif_type="vlan"} 45678
+		sonic_rif_entries_skipped 0
+		sonic_rif_entries_truncated 0
+		sonic_rif_error_packets_total{direction="rx",namespace="",rif="Ethernet0",rif_type="port"} 5
+		sonic_rif_error_packets_total{direction="rx",namespace="",rif="PortChannel0001",rif_type="unknown"} 0
+		sonic_rif_error_packets_total{direction="rx",namespace="",rif="Vlan1000",rif_type="vlan"} 0
+		sonic_rif_error_packets_total{direction="tx",namespace="",rif="Ethernet0",rif_type="port"} 0
+		sonic_rif_error_packets_total{direction="tx",namespace="",rif="PortChannel0001",rif_type="unknown"} 0
+		sonic_rif_error_packets_total{direction="tx",namespace="",rif="Vlan1000",rif_type="vlan"} 2
+	`
+
+	if err := testutil.CollectAndCompare(rifCollector, strings.NewReader(metadata+expected),
+		"sonic_rif_bytes_total", "sonic_rif_entries_skipped", "sonic_rif_entries_truncated",
+		"sonic_rif_error_packets_total"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	for _, name := range []string{"sonic_rif_packets_total", "sonic_rif_error_bytes_total", "sonic_rif_collector_success"} {
+		assertMetricFamilyPresence(t, rifCollector, name, true)
+	}
+}
+
+func TestRifCollectorMaxInterfaces(t *testing.T) {
+	promslogConfig := &promslog.Config{}
+	logger := promslog.New(promslogConfig)
+
+	t.Setenv("RIF_ENABLED", "true")
+	t.Setenv("RIF_MAX_INTERFACES", "1")
+	rifCollector := NewRifCollector(logger, newTestMetricFilter(t, logger), testRedisClient)
+
+	expected := `
+		# HELP sonic_rif_bytes_total Number of bytes on a router interface by direction: rx, tx
+		# TYPE sonic_rif_bytes_total counter
+		sonic_rif_bytes_total{direction="rx",namespace="",rif="Ethernet0",rif_type="port"} 123456
+		sonic_rif_bytes_total{direction="tx",namespace="",rif="Ethernet0",rif_type="port"} 654321
+		# HELP sonic_rif_entries_truncated Whether RIF collection hit max interfaces limit (1=yes, 0=no)
+		# TYPE sonic_rif_entries_truncated gauge
+		sonic_rif_entries_truncated 1
+	`
+
+	if err := testutil.CollectAndCompare(rifCollector, strings.NewReader(expected),
+		"sonic_rif_bytes_total", "sonic_rif_entries_truncated"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -12,6 +12,12 @@ import (
 	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
+type crmCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+}
+
 type crmCollector struct {
 	crmResourceAvailable    *prometheus.Desc
 	crmResourceUsed         *prometheus.Desc
@@ -19,11 +25,17 @@ type crmCollector struct {
 	crmAclResourceUsed      *prometheus.Desc
 	scrapeDuration          *prometheus.Desc
 	scrapeCollectorSuccess  *prometheus.Desc
-	cachedMetrics           []prometheus.Metric
-	lastScrapeTime          time.Time
-	logger                  *slog.Logger
-	metricFilter            MetricFilter
-	mu                      sync.Mutex
+	cacheAge                *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       crmCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
 }
 
 const (
@@ -33,30 +45,69 @@ const (
 	crmAclResourceUsedMetricName      = "sonic_crm_acl_resource_used"
 	crmScrapeDurationMetricName       = "sonic_crm_scrape_duration_seconds"
 	crmCollectorSuccessMetricName     = "sonic_crm_collector_success"
+	crmCacheAgeMetricName             = "sonic_crm_cache_age_seconds"
 )
 
-func NewCrmCollector(logger *slog.Logger, metricFilter MetricFilter) *crmCollector {
+func NewCrmCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *crmCollector {
+	return newCrmCollector(logger, metricFilter, redisClient, loadCrmCollectorConfig(logger))
+}
+
+func newCrmCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config crmCollectorConfig) *crmCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "crm"
 	)
 
-	return &crmCollector{
+	collector := &crmCollector{
 		crmResourceAvailable: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "resource_available"),
-			"Maximum available value for a resource", []string{"resource"}, nil),
+			"Maximum available value for a resource", []string{"resource", "namespace"}, nil),
 		crmResourceUsed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "resource_used"),
-			"Used value for a resource", []string{"resource"}, nil),
+			"Used value for a resource", []string{"resource", "namespace"}, nil),
 		crmAclResourceAvailable: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "acl_resource_available"),
-			"Maximum available value for an ACL resource", []string{"acl_target", "resource"}, nil),
+			"Maximum available value for an ACL resource", []string{"acl_target", "resource", "namespace"}, nil),
 		crmAclResourceUsed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "acl_resource_used"),
-			"Used value for an ACL resource", []string{"acl_target", "resource"}, nil),
+			"Used value for an ACL resource", []string{"acl_target", "resource", "namespace"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
-			"Time it took for prometheus to scrape sonic crm metrics", nil, nil),
+			"Time it took for exporter to refresh crm metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether crm collector succeeded", nil, nil),
+			"Whether crm collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest crm cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("CRM collector is disabled")
+		return collector
+	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *crmCollector) Name() string {
+	return "crm"
+}
+
+func (collector *crmCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *crmCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *crmCollector) Stop() {
+	close(collector.stop)
 }
 
 func (collector *crmCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -66,84 +117,94 @@ func (collector *crmCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.crmAclResourceUsed
 	ch <- collector.scrapeDuration
 	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
 }
 
 func (collector *crmCollector) Collect(ch chan<- prometheus.Metric) {
-	const cacheDuration = 15 * time.Second
-
-	scrapeSuccess := 1.0
-
-	var ctx = context.Background()
-
-	collector.mu.Lock()
-	defer collector.mu.Unlock()
+	if !collector.config.enabled {
+		return
+	}
 
-	if time.Since(collector.lastScrapeTime) < cacheDuration {
-		// Return cached metrics without making redis calls
-		collector.logger.Info("Returning crm metrics from cache")
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
 
-		for _, metric := range collector.cachedMetrics {
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
 			ch <- metric
 		}
-		return
+		if collector.metricFilter.Enabled(crmCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	err := collector.scrapeMetrics(ctx)
-	if err != nil {
-		scrapeSuccess = 0
-		collector.logger.Error("Error scraping metrics", "error", err)
+	_, _, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(crmScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled(crmCollectorSuccessMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeCollectorSuccess, prometheus.GaugeValue, scrapeSuccess,
-		))
+	if collector.metricFilter.Enabled(crmCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
+}
+
+func (collector *crmCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
 
-	for _, cachedMetric := range collector.cachedMetrics {
-		ch <- cachedMetric
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
-func (collector *crmCollector) scrapeMetrics(ctx context.Context) error {
-	collector.logger.Info("Starting crm metric scrape")
-	scrapeTime := time.Now()
+func (collector *crmCollector) refreshMetrics() {
+	start := time.Now()
 
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return fmt.Errorf("redis client initialization failed: %w", err)
-	}
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
 
-	defer redisClient.Close()
+	snapshots := refreshNamespaces(collector.logger, "CRM", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, 0, 0, err
+	})
+	scrapeDuration := time.Since(start).Seconds()
 
-	// Reset metrics
-	collector.cachedMetrics = []prometheus.Metric{}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
+
+func (collector *crmCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, error) {
+	metrics := []prometheus.Metric{}
 
 	crmStats, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "CRM:STATS")
 	if err != nil {
-		return fmt.Errorf("redis read failed: %w", err)
+		return nil, fmt.Errorf("redis read failed: %w", err)
 	}
 
-	err = collector.collectCrmStatsCounters(ctx, crmStats)
+	err = collector.collectCrmStatsCounters(&metrics, crmStats, namespace)
 	if err != nil {
-		return fmt.Errorf("crm stats collection failed: %w", err)
+		return nil, fmt.Errorf("crm stats collection failed: %w", err)
 	}
 
-	err = collector.collectCrmAclStats(ctx, redisClient)
+	err = collector.collectCrmAclStats(ctx, redisClient, &metrics, namespace)
 	if err != nil {
-		return fmt.Errorf("crm acl stats collection failed: %w", err)
+		return nil, fmt.Errorf("crm acl stats collection failed: %w", err)
 	}
 
-	collector.logger.Info("Ending crm metric scrape")
-	collector.lastScrapeTime = time.Now()
-	if collector.metricFilter.Enabled(crmScrapeDurationMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeDuration, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(),
-		))
-	}
-	return nil
+	return metrics, nil
 }
 
-func (collector *crmCollector) collectCrmStatsCounters(ctx context.Context, crmStats map[string]string) error {
+func (collector *crmCollector) collectCrmStatsCounters(metrics *[]prometheus.Metric, crmStats map[string]string, namespace string) error {
 	for stat, value := range crmStats {
 		parsedValue, err := parseFloat(value)
 		if err != nil {
@@ -153,8 +214,8 @@ func (collector *crmCollector) collectCrmStatsCounters(ctx context.Context, crmS
 		if strings.HasSuffix(stat, "available") {
 			label := strings.TrimSuffix(strings.TrimPrefix(stat, "crm_stats_"), "_available")
 			if collector.metricFilter.Enabled(crmResourceAvailableMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-					collector.crmResourceAvailable, prometheus.GaugeValue, parsedValue, label,
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					collector.crmResourceAvailable, prometheus.GaugeValue, parsedValue, label, namespace,
 				))
 			}
 		}
@@ -162,8 +223,8 @@ func (collector *crmCollector) collectCrmStatsCounters(ctx context.Context, crmS
 		if strings.HasSuffix(stat, "used") {
 			label := strings.TrimSuffix(strings.TrimPrefix(stat, "crm_stats_"), "_used")
 			if collector.metricFilter.Enabled(crmResourceUsedMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-					collector.crmResourceUsed, prometheus.GaugeValue, parsedValue, label,
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					collector.crmResourceUsed, prometheus.GaugeValue, parsedValue, label, namespace,
 				))
 			}
 		}
@@ -172,7 +233,7 @@ func (collector *crmCollector) collectCrmStatsCounters(ctx context.Context, crmS
 	return nil
 }
 
-func (collector *crmCollector) collectCrmAclStats(ctx context.Context, redisClient redis.Client) error {
+func (collector *crmCollector) collectCrmAclStats(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, namespace string) error {
 	crmAclKeys, err := redisClient.KeysFromDb(ctx, "COUNTERS_DB", "CRM:ACL_STATS:*")
 	if err != nil {
 		return fmt.Errorf("redis read failed: %w", err)
@@ -193,8 +254,8 @@ func (collector *crmCollector) collectCrmAclStats(ctx context.Context, redisClie
 			if strings.HasSuffix(stat, "available") {
 				label := strings.TrimSuffix(strings.TrimPrefix(stat, "crm_stats_"), "_available")
 				if collector.metricFilter.Enabled(crmAclResourceAvailableMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.crmAclResourceAvailable, prometheus.GaugeValue, parsedValue, aclTarget, label,
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.crmAclResourceAvailable, prometheus.GaugeValue, parsedValue, aclTarget, label, namespace,
 					))
 				}
 			}
@@ -202,8 +263,8 @@ func (collector *crmCollector) collectCrmAclStats(ctx context.Context, redisClie
 			if strings.HasSuffix(stat, "used") {
 				label := strings.TrimSuffix(strings.TrimPrefix(stat, "crm_stats_"), "_used")
 				if collector.metricFilter.Enabled(crmAclResourceUsedMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.crmAclResourceUsed, prometheus.GaugeValue, parsedValue, aclTarget, label,
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.crmAclResourceUsed, prometheus.GaugeValue, parsedValue, aclTarget, label, namespace,
 					))
 				}
 			}
@@ -211,3 +272,11 @@ func (collector *crmCollector) collectCrmAclStats(ctx context.Context, redisClie
 	}
 	return nil
 }
+
+func loadCrmCollectorConfig(logger *slog.Logger) crmCollectorConfig {
+	return crmCollectorConfig{
+		enabled:         parseBoolEnv(logger, "CRM_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "CRM_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "CRM_TIMEOUT", 2*time.Second),
+	}
+}
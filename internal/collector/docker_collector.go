@@ -45,7 +45,9 @@ type dockerCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       dockerCollectorConfig
+	stop         chan struct{}
 
 	mu                   sync.RWMutex
 	cachedMetrics        []prometheus.Metric
@@ -58,7 +60,11 @@ type dockerCollector struct {
 	lastContainerCount   float64
 }
 
-func NewDockerCollector(logger *slog.Logger, metricFilter MetricFilter) *dockerCollector {
+func NewDockerCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *dockerCollector {
+	return newDockerCollector(logger, metricFilter, redisClient, loadDockerCollectorConfig(logger))
+}
+
+func newDockerCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config dockerCollectorConfig) *dockerCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "docker"
@@ -103,7 +109,9 @@ func NewDockerCollector(logger *slog.Logger, metricFilter MetricFilter) *dockerC
 			"Age of latest docker cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config:       loadDockerCollectorConfig(logger),
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -121,6 +129,21 @@ func (collector *dockerCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *dockerCollector) Name() string {
+	return "docker"
+}
+
+func (collector *dockerCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *dockerCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *dockerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.containerInfo
 	ch <- collector.containerCPUPercent
@@ -207,8 +230,13 @@ func (collector *dockerCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -241,11 +269,7 @@ func (collector *dockerCollector) refreshMetrics() {
 }
 
 func (collector *dockerCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, int, time.Time, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, time.Time{}, 1, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	sourceUpdateTime, sourceStale, err := collector.readLastUpdateTime(ctx, redisClient)
 	if err != nil {
@@ -44,15 +44,13 @@ type fdbCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       fdbCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
-	cachedMetrics      []prometheus.Metric
-	lastSuccess        float64
+	snapshots          map[string]namespaceSnapshot
 	lastScrapeDuration float64
-	lastSkippedEntries float64
-	lastTruncated      float64
-	lastRefreshTime    time.Time
 }
 
 type fdbEntryKey struct {
@@ -61,7 +59,11 @@ type fdbEntryKey struct {
 	Mac  string `json:"mac"`
 }
 
-func NewFdbCollector(logger *slog.Logger, metricFilter MetricFilter) *fdbCollector {
+func NewFdbCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *fdbCollector {
+	return newFdbCollector(logger, metricFilter, redisClient, loadFdbCollectorConfig(logger))
+}
+
+func newFdbCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config fdbCollectorConfig) *fdbCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "fdb"
@@ -69,15 +71,15 @@ func NewFdbCollector(logger *slog.Logger, metricFilter MetricFilter) *fdbCollect
 
 	collector := &fdbCollector{
 		fdbEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries"),
-			"Number of FDB entries", nil, nil),
+			"Number of FDB entries", []string{"namespace"}, nil),
 		fdbEntriesByPort: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_by_port"),
-			"Number of FDB entries by port", []string{"port"}, nil),
+			"Number of FDB entries by port", []string{"port", "namespace"}, nil),
 		fdbEntriesByVlan: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_by_vlan"),
-			"Number of FDB entries by VLAN", []string{"vlan"}, nil),
+			"Number of FDB entries by VLAN", []string{"vlan", "namespace"}, nil),
 		fdbEntriesByType: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_by_type"),
-			"Number of FDB entries by entry type", []string{"entry_type"}, nil),
+			"Number of FDB entries by entry type", []string{"entry_type", "namespace"}, nil),
 		fdbEntriesUnknownVlan: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_unknown_vlan"),
-			"Number of FDB entries with unknown VLAN mapping", nil, nil),
+			"Number of FDB entries with unknown VLAN mapping", []string{"namespace"}, nil),
 		fdbEntriesTruncated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_truncated"),
 			"Whether FDB collection hit max entries limit (1=yes, 0=no)", nil, nil),
 		fdbEntriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
@@ -85,12 +87,14 @@ func NewFdbCollector(logger *slog.Logger, metricFilter MetricFilter) *fdbCollect
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
 			"Time it took for exporter to refresh FDB metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether FDB collector succeeded", nil, nil),
+			"Whether FDB collector succeeded", []string{"namespace"}, nil),
 		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
 			"Age of latest FDB cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config:       loadFdbCollectorConfig(logger),
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -108,6 +112,21 @@ func (collector *fdbCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *fdbCollector) Name() string {
+	return "fdb"
+}
+
+func (collector *fdbCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *fdbCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *fdbCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.fdbEntries
 	ch <- collector.fdbEntriesByPort
@@ -127,22 +146,20 @@ func (collector *fdbCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	collector.mu.RLock()
-	cachedMetrics := append([]prometheus.Metric{}, collector.cachedMetrics...)
-	lastSkippedEntries := collector.lastSkippedEntries
-	lastTruncated := collector.lastTruncated
+	snapshots := collector.snapshots
 	lastScrapeDuration := collector.lastScrapeDuration
-	lastSuccess := collector.lastSuccess
-	lastRefreshTime := collector.lastRefreshTime
 	collector.mu.RUnlock()
 
-	for _, metric := range cachedMetrics {
-		ch <- metric
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled("sonic_fdb_collector_success") {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	cacheAge := 0.0
-	if !lastRefreshTime.IsZero() {
-		cacheAge = time.Since(lastRefreshTime).Seconds()
-	}
+	lastSkippedEntries, lastTruncated, cacheAge := summarizeNamespaces(snapshots)
 
 	if collector.metricFilter.Enabled("sonic_fdb_entries_skipped") {
 		ch <- prometheus.MustNewConstMetric(collector.fdbEntriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
@@ -153,9 +170,6 @@ func (collector *fdbCollector) Collect(ch chan<- prometheus.Metric) {
 	if collector.metricFilter.Enabled("sonic_fdb_scrape_duration_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled("sonic_fdb_collector_success") {
-		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
-	}
 	if collector.metricFilter.Enabled("sonic_fdb_cache_age_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
@@ -165,44 +179,34 @@ func (collector *fdbCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
 func (collector *fdbCollector) refreshMetrics() {
 	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
-	defer cancel()
 
-	metrics, skippedEntries, truncated, err := collector.scrapeMetrics(ctx)
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "FDB", collector.redisClient, collector.config.timeout, previous, collector.scrapeMetrics)
 	scrapeDuration := time.Since(start).Seconds()
 
 	collector.mu.Lock()
 	defer collector.mu.Unlock()
 
 	collector.lastScrapeDuration = scrapeDuration
-
-	if err != nil {
-		collector.lastSuccess = 0
-		collector.logger.Error("Error refreshing FDB metrics", "error", err)
-		return
-	}
-
-	collector.cachedMetrics = metrics
-	collector.lastSkippedEntries = float64(skippedEntries)
-	collector.lastTruncated = truncated
-	collector.lastSuccess = 1
-	collector.lastRefreshTime = time.Now()
+	collector.snapshots = snapshots
 }
 
-func (collector *fdbCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
-
+func (collector *fdbCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
 	portOidToName, err := collector.portOidToNameMap(ctx, redisClient)
 	if err != nil {
 		return nil, 0, 0, err
@@ -233,24 +237,32 @@ func (collector *fdbCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 	entriesByPort := map[string]float64{}
 	entriesByType := map[string]float64{}
 
-	for idx, fdbKey := range fdbKeys {
-		if idx >= collector.config.maxEntries {
-			truncated = 1
-			skippedEntries += len(fdbKeys) - idx
-			break
-		}
+	if len(fdbKeys) > collector.config.maxEntries {
+		truncated = 1
+		skippedEntries += len(fdbKeys) - collector.config.maxEntries
+		fdbKeys = fdbKeys[:collector.config.maxEntries]
+	}
 
-		parsedKey, err := parseFDBKey(fdbKey)
+	parsedKeys := make([]fdbEntryKey, 0, len(fdbKeys))
+	entryKeys := make([]string, 0, len(fdbKeys))
+	for _, entryKey := range fdbKeys {
+		parsedKey, err := parseFDBKey(entryKey)
 		if err != nil {
 			skippedEntries++
 			continue
 		}
 
-		fdbData, err := redisClient.HgetAllFromDb(ctx, "ASIC_DB", fdbKey)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read FDB entry %s: %w", fdbKey, err)
-		}
+		parsedKeys = append(parsedKeys, parsedKey)
+		entryKeys = append(entryKeys, entryKey)
+	}
+
+	fdbEntries, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", entryKeys)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read FDB entries: %w", err)
+	}
 
+	for idx, parsedKey := range parsedKeys {
+		fdbData := fdbEntries[idx]
 		if len(fdbData) == 0 {
 			skippedEntries++
 			continue
@@ -279,10 +291,10 @@ func (collector *fdbCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 
 	metrics := make([]prometheus.Metric, 0, len(entriesByVLAN)+len(entriesByPort)+len(entriesByType)+2)
 	if collector.metricFilter.Enabled("sonic_fdb_entries") {
-		metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntries, prometheus.GaugeValue, entriesTotal))
+		metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntries, prometheus.GaugeValue, entriesTotal, namespace))
 	}
 	if collector.metricFilter.Enabled("sonic_fdb_entries_unknown_vlan") {
-		metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesUnknownVlan, prometheus.GaugeValue, unknownVLANEntries))
+		metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesUnknownVlan, prometheus.GaugeValue, unknownVLANEntries, namespace))
 	}
 
 	vlanNames := sortedMapKeys(entriesByVLAN)
@@ -291,8 +303,8 @@ func (collector *fdbCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 			skippedEntries += len(vlanNames) - idx
 			break
 		}
-		if collector.metricFilter.Enabled("sonic_fdb_entries_by_vlan") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByVlan, prometheus.GaugeValue, entriesByVLAN[vlanName], vlanName))
+		if collector.metricFilter.SeriesEnabled("sonic_fdb_entries_by_vlan", "vlan", vlanName, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByVlan, prometheus.GaugeValue, entriesByVLAN[vlanName], vlanName, namespace))
 		}
 	}
 
@@ -302,15 +314,15 @@ func (collector *fdbCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 			skippedEntries += len(portNames) - idx
 			break
 		}
-		if collector.metricFilter.Enabled("sonic_fdb_entries_by_port") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByPort, prometheus.GaugeValue, entriesByPort[portName], portName))
+		if collector.metricFilter.SeriesEnabled("sonic_fdb_entries_by_port", "port", portName, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByPort, prometheus.GaugeValue, entriesByPort[portName], portName, namespace))
 		}
 	}
 
 	entryTypes := sortedMapKeys(entriesByType)
 	for _, entryType := range entryTypes {
-		if collector.metricFilter.Enabled("sonic_fdb_entries_by_type") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByType, prometheus.GaugeValue, entriesByType[entryType], entryType))
+		if collector.metricFilter.SeriesEnabled("sonic_fdb_entries_by_type", "entry_type", entryType, "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.fdbEntriesByType, prometheus.GaugeValue, entriesByType[entryType], entryType, namespace))
 		}
 	}
 
@@ -349,6 +361,8 @@ func (collector *fdbCollector) bvidToVlanMap(ctx context.Context, redisClient re
 	bvidToVlan := make(map[string]string, len(vlanKeys))
 	skippedEntries := 0
 
+	bvids := make([]string, 0, len(vlanKeys))
+	objectKeys := make([]string, 0, len(vlanKeys))
 	for _, vlanKey := range vlanKeys {
 		bvid := strings.TrimPrefix(vlanKey, asciiVLANObjectPrefix)
 		if bvid == "" || bvid == vlanKey {
@@ -356,11 +370,17 @@ func (collector *fdbCollector) bvidToVlanMap(ctx context.Context, redisClient re
 			continue
 		}
 
-		vlanData, err := redisClient.HgetAllFromDb(ctx, "ASIC_DB", vlanKey)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read VLAN object %s: %w", vlanKey, err)
-		}
+		bvids = append(bvids, bvid)
+		objectKeys = append(objectKeys, vlanKey)
+	}
+
+	vlanObjects, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", objectKeys)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read VLAN objects: %w", err)
+	}
 
+	for idx, bvid := range bvids {
+		vlanData := vlanObjects[idx]
 		vlanID := strings.TrimSpace(vlanData["SAI_VLAN_ATTR_VLAN_ID"])
 		if vlanID == "" {
 			skippedEntries++
@@ -382,6 +402,8 @@ func (collector *fdbCollector) bridgePortToPortMap(ctx context.Context, redisCli
 	bridgePortToPort := make(map[string]string, len(bridgePortKeys))
 	skippedEntries := 0
 
+	bridgePortIDs := make([]string, 0, len(bridgePortKeys))
+	objectKeys := make([]string, 0, len(bridgePortKeys))
 	for _, bridgePortKey := range bridgePortKeys {
 		bridgePortID := strings.TrimPrefix(bridgePortKey, asciiBridgePortPrefix)
 		if bridgePortID == "" || bridgePortID == bridgePortKey {
@@ -389,11 +411,17 @@ func (collector *fdbCollector) bridgePortToPortMap(ctx context.Context, redisCli
 			continue
 		}
 
-		bridgePortData, err := redisClient.HgetAllFromDb(ctx, "ASIC_DB", bridgePortKey)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to read bridge port %s: %w", bridgePortKey, err)
-		}
+		bridgePortIDs = append(bridgePortIDs, bridgePortID)
+		objectKeys = append(objectKeys, bridgePortKey)
+	}
+
+	bridgePorts, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", objectKeys)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read bridge ports: %w", err)
+	}
 
+	for idx, bridgePortID := range bridgePortIDs {
+		bridgePortData := bridgePorts[idx]
 		portID := bridgePortData["SAI_BRIDGE_PORT_ATTR_PORT_ID"]
 		if portID == "" {
 			continue
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// filteredCollector applies a MetricFilter to an upstream collector, such as
+// node_exporter, that does not know about SONIC_DISABLED_METRICS.
+type filteredCollector struct {
+	collector prometheus.Collector
+	filter    func() MetricFilter
+}
+
+// NewFilteredCollector wraps collector so every scrape drops the families and
+// series disabled by the filter returned by filter. The filter is looked up
+// on each scrape so configuration reloads apply to the wrapped collector.
+func NewFilteredCollector(collector prometheus.Collector, filter func() MetricFilter) prometheus.Collector {
+	return &filteredCollector{collector: collector, filter: filter}
+}
+
+func (collector *filteredCollector) Describe(ch chan<- *prometheus.Desc) {
+	collector.collector.Describe(ch)
+}
+
+func (collector *filteredCollector) Collect(ch chan<- prometheus.Metric) {
+	collectFiltered(ch, collector.filter(), collector.collector.Collect)
+}
+
+// collectFiltered forwards the metrics sent by collect to ch unless filter
+// disables their family or series.
+func collectFiltered(ch chan<- prometheus.Metric, filter MetricFilter, collect func(chan<- prometheus.Metric)) {
+	if !filter.Active() {
+		collect(ch)
+		return
+	}
+
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collect(metrics)
+		close(metrics)
+	}()
+
+	for metric := range metrics {
+		if filter.metricEnabled(metric) {
+			ch <- metric
+		}
+	}
+}
+
+// metricEnabled reports whether an already built metric passes the filter.
+// Label values are only read when label rules are configured.
+func (filter MetricFilter) metricEnabled(metric prometheus.Metric) bool {
+	name := descMetricName(metric.Desc())
+	if name == "" {
+		// Invalid metrics are passed on so the registry reports their error
+		return true
+	}
+
+	if len(filter.labelRules) == 0 {
+		return filter.Enabled(name)
+	}
+
+	var written dto.Metric
+	if err := metric.Write(&written); err != nil {
+		return true
+	}
+
+	labels := make([]string, 0, 2*len(written.GetLabel()))
+	for _, label := range written.GetLabel() {
+		labels = append(labels, label.GetName(), label.GetValue())
+	}
+
+	return filter.SeriesEnabled(name, labels...)
+}
@@ -45,15 +45,21 @@ type frrCollectorConfig struct {
 }
 
 type frrCollector struct {
-	logger   *slog.Logger
-	config   frrCollectorConfig
-	exporter *frrcollector.Exporter
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	config       frrCollectorConfig
+	exporter     *frrcollector.Exporter
 }
 
-func NewFrrCollector(logger *slog.Logger) *frrCollector {
+func NewFrrCollector(logger *slog.Logger, metricFilter MetricFilter) *frrCollector {
+	return newFrrCollector(logger, metricFilter, loadFrrCollectorConfig(logger))
+}
+
+func newFrrCollector(logger *slog.Logger, metricFilter MetricFilter, config frrCollectorConfig) *frrCollector {
 	collector := &frrCollector{
-		logger: logger,
-		config: loadFrrCollectorConfig(logger),
+		logger:       logger,
+		metricFilter: metricFilter,
+		config:       config,
 	}
 
 	if !collector.config.enabled {
@@ -79,10 +85,29 @@ func NewFrrCollector(logger *slog.Logger) *frrCollector {
 	return collector
 }
 
+func (collector *frrCollector) withMetricFilter(metricFilter MetricFilter) SonicCollector {
+	reloaded := *collector
+	reloaded.metricFilter = metricFilter
+	return &reloaded
+}
+
 func (collector *frrCollector) IsEnabled() bool {
 	return collector.config.enabled && collector.exporter != nil
 }
 
+func (collector *frrCollector) Name() string {
+	return "frr"
+}
+
+// Health is always successful, FRR scrape errors are reported by the
+// frr_exporter metrics themselves.
+func (collector *frrCollector) Health() CollectorHealth {
+	return CollectorHealth{Success: true}
+}
+
+// Stop is a no-op, frrCollector has no background refresh.
+func (collector *frrCollector) Stop() {}
+
 func (collector *frrCollector) Describe(ch chan<- *prometheus.Desc) {
 	if !collector.IsEnabled() {
 		return
@@ -96,7 +121,7 @@ func (collector *frrCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
-	collector.exporter.Collect(ch)
+	collectFiltered(ch, collector.metricFilter, collector.exporter.Collect)
 }
 
 func loadFrrCollectorConfig(logger *slog.Logger) frrCollectorConfig {
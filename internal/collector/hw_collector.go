@@ -14,6 +14,12 @@ import (
 	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
+type hwCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+}
+
 type hwCollector struct {
 	hwPsuInfo               *prometheus.Desc
 	hwPsuVoltageVolts       *prometheus.Desc
@@ -28,11 +34,19 @@ type hwCollector struct {
 	hwChassisInfo           *prometheus.Desc
 	scrapeDuration          *prometheus.Desc
 	scrapeCollectorSuccess  *prometheus.Desc
-	cachedMetrics           []prometheus.Metric
-	lastScrapeTime          time.Time
-	logger                  *slog.Logger
-	metricFilter            MetricFilter
-	mu                      sync.Mutex
+	cacheAge                *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       hwCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	cachedMetrics      []prometheus.Metric
+	lastSuccess        float64
+	lastScrapeDuration float64
+	lastRefreshTime    time.Time
 }
 
 const (
@@ -49,15 +63,20 @@ const (
 	hwChassisInfoMetricName           = "sonic_hw_chassis_info"
 	hwScrapeDurationMetricName        = "sonic_hw_scrape_duration_seconds"
 	hwCollectorSuccessMetricName      = "sonic_hw_collector_success"
+	hwCacheAgeMetricName              = "sonic_hw_cache_age_seconds"
 )
 
-func NewHwCollector(logger *slog.Logger, metricFilter MetricFilter) *hwCollector {
+func NewHwCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *hwCollector {
+	return newHwCollector(logger, metricFilter, redisClient, loadHwCollectorConfig(logger))
+}
+
+func newHwCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config hwCollectorConfig) *hwCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "hw"
 	)
 
-	return &hwCollector{
+	collector := &hwCollector{
 		hwPsuInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "psu_info"),
 			"Non-numeric data about PSU, value is always 1", []string{"slot", "serial", "model_name", "model"}, nil),
 		hwPsuVoltageVolts: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "psu_voltage_volts"),
@@ -81,12 +100,46 @@ func NewHwCollector(logger *slog.Logger, metricFilter MetricFilter) *hwCollector
 		hwChassisInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "chassis_info"),
 			"Non-numeric data about chassis, value is always 1", []string{"name", "psu_num", "serial", "model"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
-			"Time it took for prometheus to scrape sonic hw metrics", nil, nil),
+			"Time it took for exporter to refresh hw metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
 			"Whether hw collector succeeded", nil, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest hw cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("HW collector is disabled")
+		return collector
 	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *hwCollector) Name() string {
+	return "hw"
+}
+
+func (collector *hwCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *hwCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *hwCollector) Stop() {
+	close(collector.stop)
 }
 
 func (collector *hwCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -103,85 +156,98 @@ func (collector *hwCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.hwChassisInfo
 	ch <- collector.scrapeDuration
 	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
 }
 
 func (collector *hwCollector) Collect(ch chan<- prometheus.Metric) {
-	const cacheDuration = 15 * time.Second
-
-	scrapeSuccess := 1.0
-
-	var ctx = context.Background()
-
-	collector.mu.Lock()
-	defer collector.mu.Unlock()
+	if !collector.config.enabled {
+		return
+	}
 
-	if time.Since(collector.lastScrapeTime) < cacheDuration {
-		// Return cached metrics without making redis calls
-		collector.logger.Info("Returning hw metrics from cache")
+	collector.mu.RLock()
+	cachedMetrics := collector.cachedMetrics
+	lastScrapeDuration := collector.lastScrapeDuration
+	lastSuccess := collector.lastSuccess
+	lastRefreshTime := collector.lastRefreshTime
+	collector.mu.RUnlock()
 
-		for _, metric := range collector.cachedMetrics {
-			ch <- metric
-		}
-		return
+	for _, metric := range cachedMetrics {
+		ch <- metric
 	}
 
-	err := collector.scrapeMetrics(ctx)
-	if err != nil {
-		scrapeSuccess = 0
-		collector.logger.Error("Error scraping metrics", "error", err)
+	cacheAge := 0.0
+	if !lastRefreshTime.IsZero() {
+		cacheAge = time.Since(lastRefreshTime).Seconds()
+	}
+	if collector.metricFilter.Enabled(hwScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
 	if collector.metricFilter.Enabled(hwCollectorSuccessMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeCollectorSuccess, prometheus.GaugeValue, scrapeSuccess,
-		))
+		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
 	}
+	if collector.metricFilter.Enabled(hwCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
+	}
+}
+
+func (collector *hwCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
 
-	for _, cachedMetric := range collector.cachedMetrics {
-		ch <- cachedMetric
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
-func (collector *hwCollector) scrapeMetrics(ctx context.Context) error {
-	collector.logger.Info("Starting hw metric scrape")
-	scrapeTime := time.Now()
+// refreshMetrics keeps the previous metrics when a refresh fails.
+func (collector *hwCollector) refreshMetrics() {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
+	defer cancel()
+	metrics, err := collector.scrapeMetrics(ctx)
+	scrapeDuration := time.Since(start).Seconds()
 
-	redisClient, err := redis.NewClient()
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.lastScrapeDuration = scrapeDuration
 	if err != nil {
-		return fmt.Errorf("redis client initialization failed: %w", err)
+		collector.lastSuccess = 0
+		collector.logger.Error("Error refreshing hw metrics", "error", err)
+		return
 	}
+	collector.cachedMetrics = metrics
+	collector.lastSuccess = 1
+	collector.lastRefreshTime = time.Now()
+}
 
-	defer redisClient.Close()
-
-	// Reset metrics
-	collector.cachedMetrics = []prometheus.Metric{}
+func (collector *hwCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, error) {
+	redisClient := collector.redisClient
+	metrics := []prometheus.Metric{}
 
-	err = collector.collectPsuInfo(ctx, redisClient)
+	err := collector.collectPsuInfo(ctx, redisClient, &metrics)
 	if err != nil {
-		return fmt.Errorf("hw psu info collection failed: %w", err)
+		return nil, fmt.Errorf("hw psu info collection failed: %w", err)
 	}
 
-	err = collector.collectFanInfo(ctx, redisClient)
+	err = collector.collectFanInfo(ctx, redisClient, &metrics)
 	if err != nil {
-		return fmt.Errorf("hw psu info collection failed: %w", err)
+		return nil, fmt.Errorf("hw fan info collection failed: %w", err)
 	}
 
-	err = collector.collectChassisInfo(ctx, redisClient)
+	err = collector.collectChassisInfo(ctx, redisClient, &metrics)
 	if err != nil {
-		return fmt.Errorf("hw chassis info collection failed: %w", err)
+		return nil, fmt.Errorf("hw chassis info collection failed: %w", err)
 	}
 
-	collector.logger.Info("Ending hw metric scrape")
-
-	collector.lastScrapeTime = time.Now()
-	if collector.metricFilter.Enabled(hwScrapeDurationMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeDuration, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(),
-		))
-	}
-	return nil
+	return metrics, nil
 }
 
-func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient redis.Client) error {
+func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric) error {
 	const psuKeyPattern string = "PSU_INFO|PSU*"
 
 	psuKeys, err := redisClient.KeysFromDb(ctx, "STATE_DB", psuKeyPattern)
@@ -204,7 +270,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 		model := data["model"]
 
 		if collector.metricFilter.Enabled(hwPsuInfoMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwPsuInfo, prometheus.GaugeValue, 1, psuId, serial, modelName, model,
 			))
 		}
@@ -213,7 +279,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 			operational_status = 1.0
 		}
 		if collector.metricFilter.Enabled(hwPsuOperationalStatusMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwPsuOperationalStatus, prometheus.GaugeValue, operational_status, psuId,
 			))
 		}
@@ -222,7 +288,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 			available_status = 1.0
 		}
 		if collector.metricFilter.Enabled(hwPsuAvailableStatusMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwPsuAvailableStatus, prometheus.GaugeValue, available_status, psuId,
 			))
 		}
@@ -231,7 +297,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 		volts, err := parsePsuFloat(data["voltage"])
 		if err == nil {
 			if collector.metricFilter.Enabled(hwPsuVoltageVoltsMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
 					collector.hwPsuVoltageVolts, prometheus.GaugeValue, volts, psuId,
 				))
 			}
@@ -240,7 +306,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 		amperes, err := parsePsuFloat(data["current"])
 		if err == nil {
 			if collector.metricFilter.Enabled(hwPsuCurrentAmperesMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
 					collector.hwPsuCurrentAmperes, prometheus.GaugeValue, amperes, psuId,
 				))
 			}
@@ -249,7 +315,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 		power, err := parsePsuFloat(data["power"])
 		if err == nil {
 			if collector.metricFilter.Enabled(hwPsuPowerWattsMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
 					collector.hwPsuPowerWatts, prometheus.GaugeValue, power, psuId,
 				))
 			}
@@ -258,7 +324,7 @@ func (collector *hwCollector) collectPsuInfo(ctx context.Context, redisClient re
 		temp, err := parseFloat(data["temp"])
 		if err == nil {
 			if collector.metricFilter.Enabled(hwPsuTemperatureCelsiusMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
 					collector.hwPsuTemperatureCelsius, prometheus.GaugeValue, temp, psuId,
 				))
 			}
@@ -282,7 +348,7 @@ func parsePsuFloat(value string) (float64, error) {
 	return parsedValue, nil
 }
 
-func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient redis.Client) error {
+func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric) error {
 	const fanKeyPattern string = "FAN_INFO|*"
 	fanRegex := regexp.MustCompile(`(?i)FAN_INFO\|(PSU\d+|Fantray\d+)(\s|\-)(.+)`)
 
@@ -320,7 +386,7 @@ func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient re
 			operational_status = 1.0
 		}
 		if collector.metricFilter.Enabled(hwFanOperationalStatusMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwFanOperationalStatus, prometheus.GaugeValue, operational_status, fanName, fanSlot,
 			))
 		}
@@ -329,7 +395,7 @@ func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient re
 			available_status = 1.0
 		}
 		if collector.metricFilter.Enabled(hwFanAvailableStatusMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwFanAvailableStatus, prometheus.GaugeValue, available_status, fanName, fanSlot,
 			))
 		}
@@ -337,7 +403,7 @@ func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient re
 		fanRpm, err := parseFloat(data["speed"])
 		if err == nil {
 			if collector.metricFilter.Enabled(hwFanRpmMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
 					collector.hwFanRpm, prometheus.GaugeValue, fanRpm, fanName, fanSlot,
 				))
 			}
@@ -347,7 +413,7 @@ func (collector *hwCollector) collectFanInfo(ctx context.Context, redisClient re
 	return nil
 }
 
-func (collector *hwCollector) collectChassisInfo(ctx context.Context, redisClient redis.Client) error {
+func (collector *hwCollector) collectChassisInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric) error {
 	const chassisKeyPattern string = "CHASSIS_INFO|*"
 
 	chasisKeys, err := redisClient.KeysFromDb(ctx, "STATE_DB", chassisKeyPattern)
@@ -368,7 +434,7 @@ func (collector *hwCollector) collectChassisInfo(ctx context.Context, redisClien
 		model := data["model"]
 
 		if collector.metricFilter.Enabled(hwChassisInfoMetricName) {
-			collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
 				collector.hwChassisInfo, prometheus.GaugeValue, 1, chassisId, psuNum, serial, model,
 			))
 		}
@@ -376,3 +442,11 @@ func (collector *hwCollector) collectChassisInfo(ctx context.Context, redisClien
 
 	return nil
 }
+
+func loadHwCollectorConfig(logger *slog.Logger) hwCollectorConfig {
+	return hwCollectorConfig{
+		enabled:         parseBoolEnv(logger, "HW_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "HW_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "HW_TIMEOUT", 2*time.Second),
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,29 +17,82 @@ import (
 )
 
 const (
-	interfaceInfoMetricName                    = "sonic_interface_info"
-	interfaceMtuMetricName                     = "sonic_interface_mtu_bytes"
-	interfaceSpeedMetricName                   = "sonic_interface_speed_bytes"
-	interfaceAdminStatusMetricName             = "sonic_interface_admin_status"
-	interfaceOperationalStatusMetricName       = "sonic_interface_operational_status"
-	interfaceTransceiverTemperatureMetricName  = "sonic_interface_transceiver_temperature_celsius"
-	interfaceTransceiverVoltageMetricName      = "sonic_interface_transceiver_voltage"
-	interfaceOpticTransmitPowerMetricName      = "sonic_interface_optic_transmit_power_dbm"
-	interfaceTransmitEthernetPacketsMetricName = "sonic_interface_transmit_ethernet_packets_total"
-	interfaceTransmitPacketsMetricName         = "sonic_interface_transmit_packets_total"
-	interfaceTransmitBytesMetricName           = "sonic_interface_transmit_bytes_total"
-	interfaceTransmitErrsMetricName            = "sonic_interface_transmit_errs_total"
-	interfaceOpticReceivePowerMetricName       = "sonic_interface_optic_receive_power_dbm"
-	interfaceReceiveEthernetPacketsMetricName  = "sonic_interface_receive_ethernet_packets_total"
-	interfaceReceivePacketsMetricName          = "sonic_interface_receive_packets_total"
-	interfaceReceiveBytesMetricName            = "sonic_interface_receive_bytes_total"
-	interfaceReceiveErrsMetricName             = "sonic_interface_receive_errs_total"
-	interfaceScrapeDurationMetricName          = "sonic_interface_scrape_duration_seconds"
-	interfaceCollectorSuccessMetricName        = "sonic_interface_collector_success"
+	interfaceInfoMetricName                     = "sonic_interface_info"
+	interfaceMtuMetricName                      = "sonic_interface_mtu_bytes"
+	interfaceSpeedMetricName                    = "sonic_interface_speed_bytes"
+	interfaceAdminStatusMetricName              = "sonic_interface_admin_status"
+	interfaceOperationalStatusMetricName        = "sonic_interface_operational_status"
+	interfaceTransceiverTemperatureMetricName   = "sonic_interface_transceiver_temperature_celsius"
+	interfaceTransceiverVoltageMetricName       = "sonic_interface_transceiver_voltage"
+	interfaceOpticTransmitPowerMetricName       = "sonic_interface_optic_transmit_power_dbm"
+	interfaceTransmitEthernetPacketsMetricName  = "sonic_interface_transmit_ethernet_packets_total"
+	interfaceTransmitPacketsMetricName          = "sonic_interface_transmit_packets_total"
+	interfaceTransmitBytesMetricName            = "sonic_interface_transmit_bytes_total"
+	interfaceTransmitErrsMetricName             = "sonic_interface_transmit_errs_total"
+	interfaceOpticReceivePowerMetricName        = "sonic_interface_optic_receive_power_dbm"
+	interfaceReceiveEthernetPacketsMetricName   = "sonic_interface_receive_ethernet_packets_total"
+	interfaceReceivePacketsMetricName           = "sonic_interface_receive_packets_total"
+	interfaceReceiveBytesMetricName             = "sonic_interface_receive_bytes_total"
+	interfaceReceiveErrsMetricName              = "sonic_interface_receive_errs_total"
+	interfaceScrapeDurationMetricName           = "sonic_interface_scrape_duration_seconds"
+	interfaceCollectorSuccessMetricName         = "sonic_interface_collector_success"
+	interfaceCacheAgeMetricName                 = "sonic_interface_cache_age_seconds"
+	interfaceEntriesSkippedMetricName           = "sonic_interface_entries_skipped"
+	interfaceCounterParseErrorsMetricName       = "sonic_interface_counter_parse_errors_total"
+	interfaceSaiStatMetricName                  = "sonic_interface_sai_stat_total"
+	interfaceEntriesTruncatedMetricName         = "sonic_interface_entries_truncated"
+	interfaceFecFramesMetricName                = "sonic_interface_fec_frames_total"
+	interfaceFecSymbolErrorsMetricName          = "sonic_interface_fec_symbol_errors_total"
+	interfaceFecCorrectedBitsMetricName         = "sonic_interface_fec_corrected_bits_total"
+	interfaceFecCodewordErrorsMetricName        = "sonic_interface_fec_codeword_errors_total"
+	interfaceFecPreBerMetricName                = "sonic_interface_fec_pre_ber"
+	interfaceFecPostBerMetricName               = "sonic_interface_fec_post_ber"
+	interfacePfcFramesMetricName                = "sonic_interface_pfc_frames_total"
+	interfacePfcPauseDurationMetricName         = "sonic_interface_pfc_pause_duration_seconds_total"
+	interfaceReceiveBitsPerSecondMetricName     = "sonic_interface_receive_bits_per_second"
+	interfaceTransmitBitsPerSecondMetricName    = "sonic_interface_transmit_bits_per_second"
+	interfaceReceivePacketsPerSecondMetricName  = "sonic_interface_receive_packets_per_second"
+	interfaceTransmitPacketsPerSecondMetricName = "sonic_interface_transmit_packets_per_second"
+	interfaceUtilizationMetricName              = "sonic_interface_utilization_ratio"
+	interfaceRateSmoothingAlphaMetricName       = "sonic_interface_rate_smoothing_alpha"
+	interfaceRateSmoothingIntervalMetricName    = "sonic_interface_rate_smoothing_interval_seconds"
+)
+
+const (
+	fecCorrectedBitsKey        = "SAI_PORT_STAT_IF_IN_FEC_CORRECTED_BITS"
+	fecNotCorrectableFramesKey = "SAI_PORT_STAT_IF_IN_FEC_NOT_CORRECTABLE_FRAMES"
+	fecPreBerKey               = "FEC_PRE_BER"
+	fecPostBerKey              = "FEC_POST_BER"
+	// fecCodewordBits is the number of bits one uncorrectable frame is
+	// counted as in the post-FEC BER, like in SONiC port_rates.lua
+	fecCodewordBits = 5280
 )
 
 type packetSize string
 
+var fecCodewordErrorsRegex = regexp.MustCompile(`^SAI_PORT_STAT_IF_IN_FEC_CODEWORD_ERRORS_S(\d+)$`)
+
+// fecSample is the FEC counter state of a port at the previous refresh, the
+// BER gauges are computed from the difference to it.
+type fecSample struct {
+	correctedBits          float64
+	hasCorrectedBits       bool
+	uncorrectableFrames    float64
+	hasUncorrectableFrames bool
+	time                   time.Time
+}
+
+type interfaceCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+	// saiStats selects COUNTERS_DB port fields exported as-is, at most
+	// maxSaiStats of them per port
+	saiStats    metricPatterns
+	maxSaiStats int
+	pfcEnabled  bool
+}
+
 type interfaceCollector struct {
 	interfaceInfo                    *prometheus.Desc
 	interfaceMtu                     *prometheus.Desc
@@ -57,147 +113,350 @@ type interfaceCollector struct {
 	interfaceReceiveErrs             *prometheus.Desc
 	scrapeDuration                   *prometheus.Desc
 	scrapeCollectorSuccess           *prometheus.Desc
-	cachedMetrics                    []prometheus.Metric
-	lastScrapeTime                   time.Time
-	logger                           *slog.Logger
-	metricFilter                     MetricFilter
-	mu                               sync.Mutex
+	cacheAge                         *prometheus.Desc
+	entriesSkipped                   *prometheus.Desc
+	counterParseErrors               *prometheus.Desc
+	saiStat                          *prometheus.Desc
+	entriesTruncated                 *prometheus.Desc
+	fecFrames                        *prometheus.Desc
+	fecSymbolErrors                  *prometheus.Desc
+	fecCorrectedBits                 *prometheus.Desc
+	fecCodewordErrors                *prometheus.Desc
+	fecPreBer                        *prometheus.Desc
+	fecPostBer                       *prometheus.Desc
+	pfcFrames                        *prometheus.Desc
+	pfcPauseDuration                 *prometheus.Desc
+	receiveBitsPerSecond             *prometheus.Desc
+	transmitBitsPerSecond            *prometheus.Desc
+	receivePacketsPerSecond          *prometheus.Desc
+	transmitPacketsPerSecond         *prometheus.Desc
+	utilization                      *prometheus.Desc
+	rateSmoothingAlpha               *prometheus.Desc
+	rateSmoothingInterval            *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       interfaceCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+	parseErrors        map[string]float64
+
+	// fecSamples holds FEC counters per namespace and port. It is updated by
+	// refreshMetrics and read once by the collector replacing this one on
+	// reload, fecSamplesMu guards it.
+	fecSamplesMu sync.Mutex
+	fecSamples   map[string]map[string]fecSample
+}
+
+func NewInterfaceCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *interfaceCollector {
+	return newInterfaceCollector(logger, metricFilter, redisClient, loadInterfaceCollectorConfig(logger), nil)
 }
 
-func NewInterfaceCollector(logger *slog.Logger, metricFilter MetricFilter) *interfaceCollector {
+// newInterfaceCollector computes the first FEC BER from fecSamples, which may
+// be nil to start without a previous sample.
+func newInterfaceCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config interfaceCollectorConfig, fecSamples map[string]map[string]fecSample) *interfaceCollector {
+	if fecSamples == nil {
+		fecSamples = map[string]map[string]fecSample{}
+	}
+
 	const (
 		namespace = "sonic"
 		subsystem = "interface"
 	)
 
-	return &interfaceCollector{
+	collector := &interfaceCollector{
 		interfaceInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "info"),
-			"Non-numeric data about interface, value is always 1", []string{"device", "alias", "index", "description"}, nil),
+			"Non-numeric data about interface, value is always 1", []string{"device", "alias", "index", "description", "namespace"}, nil),
 		interfaceMtu: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mtu_bytes"),
-			"Network device property: mtu_bytes", []string{"device"}, nil),
+			"Network device property: mtu_bytes", []string{"device", "namespace"}, nil),
 		interfaceSpeed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "speed_bytes"),
-			"Network device property: speed_bytes", []string{"device"}, nil),
+			"Network device property: speed_bytes", []string{"device", "namespace"}, nil),
 		interfaceAdminStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "admin_status"),
-			"Network device administrative status: 0(DOWN), 1(UP)", []string{"device"}, nil),
+			"Network device administrative status: 0(DOWN), 1(UP)", []string{"device", "namespace"}, nil),
 		interfaceOperationslStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "operational_status"),
-			"Network device operational status:  0(DOWN), 1(UP)", []string{"device"}, nil),
+			"Network device operational status:  0(DOWN), 1(UP)", []string{"device", "namespace"}, nil),
 		interfaceTransceiverTemperature: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transceiver_temperature_celsius"),
-			"Network device transceiver temperature (celsius)", []string{"device"}, nil),
+			"Network device transceiver temperature (celsius)", []string{"device", "namespace"}, nil),
 		interfaceTransceiverVoltage: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transceiver_voltage"),
-			"Network device transceiver voltage", []string{"device"}, nil),
+			"Network device transceiver voltage", []string{"device", "namespace"}, nil),
 		interfaceOpticTransmitPower: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "optic_transmit_power_dbm"),
-			"Network device transceiver voltage", []string{"device", "unit"}, nil),
+			"Network device transceiver voltage", []string{"device", "unit", "namespace"}, nil),
 		interfaceTransmitEthernetPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_ethernet_packets_total"),
-			"Number of ethernet packets transmitted on an interface", []string{"device", "size"}, nil),
+			"Number of ethernet packets transmitted on an interface", []string{"device", "size", "namespace"}, nil),
 		interfaceTransmitPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_packets_total"),
-			"Number of packets transmitted on an interface", []string{"device", "method"}, nil),
+			"Number of packets transmitted on an interface", []string{"device", "method", "namespace"}, nil),
 		interfaceTransmitErrs: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_errs_total"),
-			"Number of transmit errs on an interface", []string{"device", "type"}, nil),
+			"Number of transmit errs on an interface", []string{"device", "type", "namespace"}, nil),
 		interfaceTransmitBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_bytes_total"),
-			"Number of bytes transmitted on an interface", []string{"device"}, nil),
+			"Number of bytes transmitted on an interface", []string{"device", "namespace"}, nil),
 		interfaceOpticReceivePower: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "optic_receive_power_dbm"),
-			"Network device transceiver voltage", []string{"device", "unit"}, nil),
+			"Network device transceiver voltage", []string{"device", "unit", "namespace"}, nil),
 		interfaceReceiveEthernetPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_ethernet_packets_total"),
-			"Number of ethernet packets received on an interface", []string{"device", "size"}, nil),
+			"Number of ethernet packets received on an interface", []string{"device", "size", "namespace"}, nil),
 		interfaceReceivePackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_packets_total"),
-			"Number of packets received on an interface", []string{"device", "method"}, nil),
+			"Number of packets received on an interface", []string{"device", "method", "namespace"}, nil),
 		interfaceReceiveErrs: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_errs_total"),
-			"Number of receive errs on an interface", []string{"device", "type"}, nil),
+			"Number of receive errs on an interface", []string{"device", "type", "namespace"}, nil),
 		interfaceReceivedBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_bytes_total"),
-			"Number of bytes received on an interface", []string{"device"}, nil),
+			"Number of bytes received on an interface", []string{"device", "namespace"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
-			"Time it took for prometheus to scrape sonic interface metrics", nil, nil),
+			"Time it took for exporter to refresh interface metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether interface collector succeeded", nil, nil),
+			"Whether interface collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest interface cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of interface entries skipped during latest refresh", nil, nil),
+		counterParseErrors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "counter_parse_errors_total"),
+			"Number of interface counter values that could not be parsed", []string{"counter"}, nil),
+		saiStat: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sai_stat_total"),
+			"Value of a SAI port stat selected by INTERFACE_SAI_STATS", []string{"device", "stat", "namespace"}, nil),
+		entriesTruncated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_truncated"),
+			"Whether SAI stat passthrough hit the max stats limit (1=yes, 0=no)", nil, nil),
+		fecFrames: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_frames_total"),
+			"Number of received FEC frames with errors by type: correctable, uncorrectable", []string{"device", "type", "namespace"}, nil),
+		fecSymbolErrors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_symbol_errors_total"),
+			"Number of received FEC symbol errors", []string{"device", "namespace"}, nil),
+		fecCorrectedBits: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_corrected_bits_total"),
+			"Number of bits corrected by FEC", []string{"device", "namespace"}, nil),
+		fecCodewordErrors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_codeword_errors_total"),
+			"Number of received FEC codewords by number of symbol errors in bin", []string{"device", "bin", "namespace"}, nil),
+		fecPreBer: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_pre_ber"),
+			"Bit error rate before FEC correction since the previous refresh", []string{"device", "namespace"}, nil),
+		fecPostBer: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fec_post_ber"),
+			"Bit error rate after FEC correction since the previous refresh", []string{"device", "namespace"}, nil),
+		pfcFrames: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "pfc_frames_total"),
+			"Number of PFC frames per priority and direction: rx, tx", []string{"device", "priority", "direction", "namespace"}, nil),
+		pfcPauseDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "pfc_pause_duration_seconds_total"),
+			"Time paused by PFC per priority and direction: rx, tx", []string{"device", "priority", "direction", "namespace"}, nil),
+		receiveBitsPerSecond: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_bits_per_second"),
+			"Smoothed receive rate computed by SONiC port rates", []string{"device", "namespace"}, nil),
+		transmitBitsPerSecond: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_bits_per_second"),
+			"Smoothed transmit rate computed by SONiC port rates", []string{"device", "namespace"}, nil),
+		receivePacketsPerSecond: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "receive_packets_per_second"),
+			"Smoothed receive packet rate computed by SONiC port rates", []string{"device", "namespace"}, nil),
+		transmitPacketsPerSecond: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transmit_packets_per_second"),
+			"Smoothed transmit packet rate computed by SONiC port rates", []string{"device", "namespace"}, nil),
+		utilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "utilization_ratio"),
+			"Port utilization by direction: rx, tx", []string{"device", "direction", "namespace"}, nil),
+		rateSmoothingAlpha: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rate_smoothing_alpha"),
+			"Smoothing factor SONiC port rates use", []string{"namespace"}, nil),
+		rateSmoothingInterval: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rate_smoothing_interval_seconds"),
+			"Smoothing interval SONiC port rates use", []string{"namespace"}, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+		parseErrors:  map[string]float64{},
+		fecSamples:   fecSamples,
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("Interface collector is disabled")
+		return collector
 	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
 }
 
-func (collector *interfaceCollector) Collect(ch chan<- prometheus.Metric) {
-	const cacheDuration = 15 * time.Second
+// fecSamplesOf returns a copy of the FEC samples of the interface collector
+// in collectors, nil when there is none.
+func fecSamplesOf(collectors []SonicCollector) map[string]map[string]fecSample {
+	for _, collector := range collectors {
+		previous, ok := collector.(*interfaceCollector)
+		if !ok {
+			continue
+		}
 
-	scrapeSuccess := 1.0
+		previous.fecSamplesMu.Lock()
+		defer previous.fecSamplesMu.Unlock()
 
-	var ctx = context.Background()
+		return maps.Clone(previous.fecSamples)
+	}
 
-	collector.mu.Lock()
-	defer collector.mu.Unlock()
+	return nil
+}
+
+func (collector *interfaceCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
 
-	if time.Since(collector.lastScrapeTime) < cacheDuration {
-		// Return cached metrics without making redis calls
-		collector.logger.Info("Returning interface metrics from cache")
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	counters := make([]string, 0, len(collector.parseErrors))
+	parseErrors := make(map[string]float64, len(collector.parseErrors))
+	for counter, count := range collector.parseErrors {
+		counters = append(counters, counter)
+		parseErrors[counter] = count
+	}
+	collector.mu.RUnlock()
 
-		for _, metric := range collector.cachedMetrics {
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
 			ch <- metric
 		}
-		return
+		if collector.metricFilter.Enabled(interfaceCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	err := collector.scrapeMetrics(ctx)
-	if err != nil {
-		scrapeSuccess = 0
-		collector.logger.Error("Error scraping metrics", "error", err)
+	lastSkippedEntries, lastTruncated, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(interfaceScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled(interfaceCollectorSuccessMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeCollectorSuccess, prometheus.GaugeValue, scrapeSuccess,
-		))
+	if collector.metricFilter.Enabled(interfaceCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
-
-	for _, cachedMetric := range collector.cachedMetrics {
-		ch <- cachedMetric
+	if collector.metricFilter.Enabled(interfaceEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
+	}
+	if collector.metricFilter.Enabled(interfaceEntriesTruncatedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesTruncated, prometheus.GaugeValue, lastTruncated)
+	}
+	if collector.metricFilter.Enabled(interfaceCounterParseErrorsMetricName) {
+		sort.Strings(counters)
+		for _, counter := range counters {
+			ch <- prometheus.MustNewConstMetric(collector.counterParseErrors, prometheus.CounterValue, parseErrors[counter], counter)
+		}
 	}
 }
 
-func (collector *interfaceCollector) scrapeMetrics(ctx context.Context) error {
-	collector.logger.Info("Starting interface metric scrape")
-	scrapeTime := time.Now()
+func (collector *interfaceCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
 
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return fmt.Errorf("redis client initialization failed: %w", err)
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
+}
 
-	defer redisClient.Close()
+func (collector *interfaceCollector) refreshMetrics() {
+	start := time.Now()
 
-	// Reset metrics
-	collector.cachedMetrics = []prometheus.Metric{}
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	parseErrors := map[string]float64{}
+	snapshots := refreshNamespaces(collector.logger, "interface", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		return collector.scrapeMetrics(ctx, redisClient, namespace, parseErrors)
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+	for counter, count := range parseErrors {
+		collector.parseErrors[counter] += count
+	}
+}
+
+// scrapeMetrics collects interface metrics of one namespace. A port or
+// transceiver that cannot be read is skipped with all of its series, a
+// counter value that cannot be parsed only drops its series and is counted
+// in parseErrors.
+func (collector *interfaceCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string, parseErrors map[string]float64) ([]prometheus.Metric, int, float64, error) {
+	metrics := []prometheus.Metric{}
+	skippedEntries := 0
+	truncated := 0.0
 
 	ports, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_PORT_NAME_MAP")
 	if err != nil {
-		return fmt.Errorf("redis read failed: %w", err)
+		return nil, 0, 0, fmt.Errorf("redis read failed: %w", err)
 	}
 
+	collector.fecSamplesMu.Lock()
+	previousFecSamples := collector.fecSamples[namespace]
+	collector.fecSamplesMu.Unlock()
+
+	fecSamples := map[string]fecSample{}
 	for port := range ports {
 		counterKey := fmt.Sprintf("COUNTERS:%s", ports[port])
 
-		err := collector.collectInterfaceCounters(ctx, redisClient, port, counterKey)
-		if err != nil {
-			return fmt.Errorf("interface counters collection failed: %w", err)
+		// A port is exported only when all of its reads succeed
+		portMetrics := []prometheus.Metric{}
+		portTruncated := 0.0
+		speed, err := collector.collectInterfaceInfo(ctx, redisClient, &portMetrics, parseErrors, port, namespace)
+		var rates map[string]string
+		if err == nil && collector.ratesEnabled() {
+			rates, err = redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "RATES:"+ports[port])
+			if err != nil {
+				err = fmt.Errorf("redis read failed: %w", err)
+			}
+		}
+		if err == nil {
+			portTruncated, err = collector.collectInterfaceCounters(ctx, redisClient, &portMetrics, parseErrors, previousFecSamples, fecSamples, rates, port, counterKey, namespace, speed)
+		}
+		if err == nil {
+			collector.collectInterfaceRates(&portMetrics, parseErrors, rates, port, namespace, speed)
 		}
-
-		err = collector.collectInterfaceInfo(ctx, redisClient, port)
 		if err != nil {
-			return fmt.Errorf("interface info collection failed: %w", err)
+			if ctx.Err() != nil {
+				return nil, 0, 0, fmt.Errorf("interface collection of %s failed: %w", port, err)
+			}
+
+			collector.logger.Debug("Skipping interface", "device", port, "namespace", namespace, "error", err)
+			delete(fecSamples, port)
+			skippedEntries++
+			continue
 		}
 
+		metrics = append(metrics, portMetrics...)
+		truncated = max(truncated, portTruncated)
 	}
 
-	err = collector.collectInterfaceOpticalInfo(ctx, redisClient)
+	skippedTransceivers, err := collector.collectInterfaceOpticalInfo(ctx, redisClient, &metrics, namespace)
 	if err != nil {
-		return fmt.Errorf("interface optical info collection failed: %w", err)
+		return nil, 0, 0, fmt.Errorf("interface optical info collection failed: %w", err)
 	}
 
-	collector.logger.Info("Ending interface metric scrape")
-
-	collector.lastScrapeTime = time.Now()
-	if collector.metricFilter.Enabled(interfaceScrapeDurationMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeDuration, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(),
-		))
+	err = collector.collectInterfaceRateConfig(ctx, redisClient, &metrics, parseErrors, namespace)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("interface rate config collection failed: %w", err)
 	}
-	return nil
+
+	collector.fecSamplesMu.Lock()
+	collector.fecSamples[namespace] = fecSamples
+	collector.fecSamplesMu.Unlock()
+
+	return metrics, skippedEntries + skippedTransceivers, truncated, nil
+}
+
+func (collector *interfaceCollector) Name() string {
+	return "interface"
+}
+
+func (collector *interfaceCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *interfaceCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *interfaceCollector) Stop() {
+	close(collector.stop)
 }
 
 func (collector *interfaceCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -220,56 +479,117 @@ func (collector *interfaceCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.interfaceReceivedBytes
 	ch <- collector.scrapeDuration
 	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
+	ch <- collector.counterParseErrors
+	ch <- collector.saiStat
+	ch <- collector.entriesTruncated
+	ch <- collector.fecFrames
+	ch <- collector.fecSymbolErrors
+	ch <- collector.fecCorrectedBits
+	ch <- collector.fecCodewordErrors
+	ch <- collector.fecPreBer
+	ch <- collector.fecPostBer
+	ch <- collector.pfcFrames
+	ch <- collector.pfcPauseDuration
+	ch <- collector.receiveBitsPerSecond
+	ch <- collector.transmitBitsPerSecond
+	ch <- collector.receivePacketsPerSecond
+	ch <- collector.transmitPacketsPerSecond
+	ch <- collector.utilization
+	ch <- collector.rateSmoothingAlpha
+	ch <- collector.rateSmoothingInterval
 }
 
-func (collector *interfaceCollector) collectInterfaceCounters(ctx context.Context, redisClient redis.Client, interfaceName, counterKey string) error {
+// collectInterfaceCounters collects the counters of one port and reports
+// whether its SAI stat passthrough was truncated. rates and speed, the port
+// speed in Mbit/s, are used for the FEC BER. The FEC counters are compared to
+// previousFecSamples and stored in fecSamples.
+func (collector *interfaceCollector) collectInterfaceCounters(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, parseErrors map[string]float64, previousFecSamples, fecSamples map[string]fecSample, rates map[string]string, interfaceName, counterKey, namespace string, speed float64) (float64, error) {
 	var counters map[string]string
 
 	// Retrieve packet counters from redis database
 	counters, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", counterKey)
 	if err != nil {
-		return fmt.Errorf("redis read failed: %w", err)
+		return 0, fmt.Errorf("redis read failed: %w", err)
 	}
 
-	err = collector.collectInterfaceByteCounters(interfaceName, counters)
-	if err != nil {
-		return fmt.Errorf("byte counters collection failed: %w", err)
+	collector.collectInterfaceByteCounters(metrics, parseErrors, interfaceName, namespace, counters)
+	collector.collectInterfaceErrCounters(metrics, parseErrors, interfaceName, namespace, counters)
+	collector.collectInterfacePacketCounters(metrics, parseErrors, interfaceName, namespace, counters)
+	collector.collectInterfacePacketSizeCounters(metrics, parseErrors, interfaceName, namespace, counters)
+	fecSamples[interfaceName] = collector.collectInterfaceFecCounters(metrics, parseErrors, previousFecSamples, interfaceName, namespace, counters, rates, speed)
+	collector.collectInterfacePfcCounters(metrics, parseErrors, interfaceName, namespace, counters)
+
+	return collector.collectInterfaceSaiStats(metrics, parseErrors, interfaceName, namespace, counters), nil
+}
+
+// collectInterfaceSaiStats exports the counters fields selected by
+// INTERFACE_SAI_STATS in sorted order, up to INTERFACE_MAX_SAI_STATS of them.
+func (collector *interfaceCollector) collectInterfaceSaiStats(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) float64 {
+	if collector.config.saiStats.empty() {
+		return 0
 	}
 
-	err = collector.collectInterfaceErrCounters(interfaceName, counters)
-	if err != nil {
-		return fmt.Errorf("err counters collection failed: %w", err)
+	stats := make([]string, 0)
+	for stat := range counters {
+		if collector.config.saiStats.match(stat) {
+			stats = append(stats, stat)
+		}
 	}
+	sort.Strings(stats)
 
-	err = collector.collectInterfacePacketCounters(interfaceName, counters)
-	if err != nil {
-		return fmt.Errorf("packet counters collection failed: %w", err)
+	truncated := 0.0
+	if len(stats) > collector.config.maxSaiStats {
+		truncated = 1
+		stats = stats[:collector.config.maxSaiStats]
 	}
 
-	err = collector.collectInterfacePacketSizeCounters(interfaceName, counters)
-	if err != nil {
-		return fmt.Errorf("packet size counters collection failed: %w", err)
+	for _, stat := range stats {
+		value, ok := parseCounter(parseErrors, counters, stat)
+		if !ok {
+			continue
+		}
+
+		if collector.metricFilter.SeriesEnabled(interfaceSaiStatMetricName, "device", interfaceName, "stat", stat, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.saiStat, prometheus.CounterValue, value, interfaceName, stat, namespace,
+			))
+		}
 	}
 
-	return nil
+	return truncated
+}
 
+// parseCounter parses the value of counter in values. An unparsable value is
+// counted in parseErrors and reported as not ok. A missing value is 0.
+func parseCounter(parseErrors map[string]float64, values map[string]string, counter string) (float64, bool) {
+	value, err := parseFloat(values[counter])
+	if err != nil {
+		parseErrors[counter]++
+		return 0, false
+	}
+
+	return value, true
 }
 
-func (collector *interfaceCollector) collectInterfaceInfo(ctx context.Context, redisClient redis.Client, interfaceName string) error {
-	err := collector.collectInterfaceConfigInfo(ctx, redisClient, interfaceName)
+// collectInterfaceInfo collects the config and status of one port and returns
+// its speed in Mbit/s, 0 when unknown.
+func (collector *interfaceCollector) collectInterfaceInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string) (float64, error) {
+	speed, err := collector.collectInterfaceConfigInfo(ctx, redisClient, metrics, parseErrors, interfaceName, namespace)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	err = collector.collectInterfaceOperationInfo(ctx, redisClient, interfaceName)
+	err = collector.collectInterfaceOperationInfo(ctx, redisClient, metrics, interfaceName, namespace)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return speed, nil
 }
 
-func (collector *interfaceCollector) collectInterfaceConfigInfo(ctx context.Context, redisClient redis.Client, interfaceName string) error {
+func (collector *interfaceCollector) collectInterfaceConfigInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string) (float64, error) {
 	var interfaceKey string = fmt.Sprintf("PORTCHANNEL|%s", interfaceName)
 
 	if strings.HasPrefix(interfaceName, "Ethernet") {
@@ -278,7 +598,7 @@ func (collector *interfaceCollector) collectInterfaceConfigInfo(ctx context.Cont
 
 	info, err := redisClient.HgetAllFromDb(ctx, "CONFIG_DB", interfaceKey)
 	if err != nil {
-		return fmt.Errorf("redis read failed: %w", err)
+		return 0, fmt.Errorf("redis read failed: %w", err)
 	}
 
 	description, ok := info["description"]
@@ -286,38 +606,31 @@ func (collector *interfaceCollector) collectInterfaceConfigInfo(ctx context.Cont
 		description = ""
 	}
 
-	mtu, err := parseFloat(info["mtu"])
-	if err != nil {
-		return fmt.Errorf("value parse failed: %w", err)
-	}
-
-	speed, err := parseFloat(info["speed"])
-	if err != nil {
-		return fmt.Errorf("value parse failed: %w", err)
-	}
+	mtu, mtuOk := parseCounter(parseErrors, info, "mtu")
+	speed, speedOk := parseCounter(parseErrors, info, "speed")
 
-	if collector.metricFilter.Enabled(interfaceInfoMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.interfaceInfo, prometheus.GaugeValue, 1, interfaceName, info["alias"], info["index"], description,
+	if collector.metricFilter.SeriesEnabled(interfaceInfoMetricName, "device", interfaceName, "alias", info["alias"], "index", info["index"], "description", description, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(
+			collector.interfaceInfo, prometheus.GaugeValue, 1, interfaceName, info["alias"], info["index"], description, namespace,
 		))
 	}
 
-	if collector.metricFilter.Enabled(interfaceMtuMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.interfaceMtu, prometheus.GaugeValue, mtu, interfaceName,
+	if mtuOk && collector.metricFilter.SeriesEnabled(interfaceMtuMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(
+			collector.interfaceMtu, prometheus.GaugeValue, mtu, interfaceName, namespace,
 		))
 	}
 
-	if collector.metricFilter.Enabled(interfaceSpeedMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.interfaceSpeed, prometheus.GaugeValue, speed*1000*1000/8, interfaceName,
+	if speedOk && collector.metricFilter.SeriesEnabled(interfaceSpeedMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(
+			collector.interfaceSpeed, prometheus.GaugeValue, speed*1000*1000/8, interfaceName, namespace,
 		))
 	}
 
-	return nil
+	return speed, nil
 }
 
-func (collector *interfaceCollector) collectInterfaceOperationInfo(ctx context.Context, redisClient redis.Client, interfaceName string) error {
+func (collector *interfaceCollector) collectInterfaceOperationInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, interfaceName, namespace string) error {
 	var (
 		portKey           string  = fmt.Sprintf("PORT_TABLE:%s", interfaceName)
 		adminStatus       float64 = 0
@@ -337,22 +650,22 @@ func (collector *interfaceCollector) collectInterfaceOperationInfo(ctx context.C
 		operationalStatus = 1
 	}
 
-	if collector.metricFilter.Enabled(interfaceAdminStatusMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.interfaceAdminStatus, prometheus.GaugeValue, adminStatus, interfaceName,
+	if collector.metricFilter.SeriesEnabled(interfaceAdminStatusMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(
+			collector.interfaceAdminStatus, prometheus.GaugeValue, adminStatus, interfaceName, namespace,
 		))
 	}
 
-	if collector.metricFilter.Enabled(interfaceOperationalStatusMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.interfaceOperationslStatus, prometheus.GaugeValue, operationalStatus, interfaceName,
+	if collector.metricFilter.SeriesEnabled(interfaceOperationalStatusMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(
+			collector.interfaceOperationslStatus, prometheus.GaugeValue, operationalStatus, interfaceName, namespace,
 		))
 	}
 
 	return nil
 }
 
-func (collector *interfaceCollector) collectInterfaceOpticalInfo(ctx context.Context, redisClient redis.Client) error {
+func (collector *interfaceCollector) collectInterfaceOpticalInfo(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, namespace string) (int, error) {
 	const transceiverKeyPattern string = "TRANSCEIVER_DOM_SENSOR|*"
 	var (
 		rxPowerRegex = regexp.MustCompile(`^rx(\d*)power$`)
@@ -361,15 +674,22 @@ func (collector *interfaceCollector) collectInterfaceOpticalInfo(ctx context.Con
 
 	transceiverKeys, err := redisClient.KeysFromDb(ctx, "STATE_DB", transceiverKeyPattern)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	skippedEntries := 0
 	for _, transceiverKey := range transceiverKeys {
 		interfaceName := strings.Split(transceiverKey, "|")[1]
 
 		data, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", transceiverKey)
 		if err != nil {
-			return err
+			if ctx.Err() != nil {
+				return 0, err
+			}
+
+			collector.logger.Debug("Skipping transceiver", "device", interfaceName, "namespace", namespace, "error", err)
+			skippedEntries++
+			continue
 		}
 
 		for metric, value := range data {
@@ -380,70 +700,68 @@ func (collector *interfaceCollector) collectInterfaceOpticalInfo(ctx context.Con
 
 			switch name := metric; {
 			case name == "temperature":
-				if collector.metricFilter.Enabled(interfaceTransceiverTemperatureMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceTransceiverTemperature, prometheus.GaugeValue, parsedValue, interfaceName,
+				if collector.metricFilter.SeriesEnabled(interfaceTransceiverTemperatureMetricName, "device", interfaceName, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceTransceiverTemperature, prometheus.GaugeValue, parsedValue, interfaceName, namespace,
 					))
 				}
 			case name == "voltage":
-				if collector.metricFilter.Enabled(interfaceTransceiverVoltageMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceTransceiverVoltage, prometheus.GaugeValue, parsedValue, interfaceName,
+				if collector.metricFilter.SeriesEnabled(interfaceTransceiverVoltageMetricName, "device", interfaceName, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceTransceiverVoltage, prometheus.GaugeValue, parsedValue, interfaceName, namespace,
 					))
 				}
 			case rxPowerRegex.MatchString(name):
 				opticUnit := rxPowerRegex.FindStringSubmatch(name)[1]
-				if collector.metricFilter.Enabled(interfaceOpticReceivePowerMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceOpticReceivePower, prometheus.GaugeValue, parsedValue, interfaceName, opticUnit,
+				if collector.metricFilter.SeriesEnabled(interfaceOpticReceivePowerMetricName, "device", interfaceName, "unit", opticUnit, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceOpticReceivePower, prometheus.GaugeValue, parsedValue, interfaceName, opticUnit, namespace,
 					))
 				}
 			case txPowerRegex.MatchString(name):
 				opticUnit := txPowerRegex.FindStringSubmatch(name)[1]
-				if collector.metricFilter.Enabled(interfaceOpticTransmitPowerMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceOpticTransmitPower, prometheus.GaugeValue, parsedValue, interfaceName, opticUnit,
+				if collector.metricFilter.SeriesEnabled(interfaceOpticTransmitPowerMetricName, "device", interfaceName, "unit", opticUnit, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceOpticTransmitPower, prometheus.GaugeValue, parsedValue, interfaceName, opticUnit, namespace,
 					))
 				}
 			}
 		}
 	}
-	return nil
+	return skippedEntries, nil
 }
 
-func (collector *interfaceCollector) collectInterfaceByteCounters(interfaceName string, counters map[string]string) error {
+func (collector *interfaceCollector) collectInterfaceByteCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) {
 	const interfaceByteCountKey = "SAI_PORT_STAT_IF_%s_OCTETS"
 
 	for _, direction := range []string{"in", "out"} {
-		bytes, err := parseFloat(counters[fmt.Sprintf(interfaceByteCountKey, strings.ToUpper(direction))])
-		if err != nil {
-			return fmt.Errorf("value parse failed: %w", err)
+		bytes, ok := parseCounter(parseErrors, counters, fmt.Sprintf(interfaceByteCountKey, strings.ToUpper(direction)))
+		if !ok {
+			continue
 		}
 
 		switch direction {
 		case "in":
-			if collector.metricFilter.Enabled(interfaceReceiveBytesMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics,
+			if collector.metricFilter.SeriesEnabled(interfaceReceiveBytesMetricName, "device", interfaceName, "namespace", namespace) {
+				*metrics = append(*metrics,
 					prometheus.MustNewConstMetric(
-						collector.interfaceReceivedBytes, prometheus.CounterValue, bytes, interfaceName,
+						collector.interfaceReceivedBytes, prometheus.CounterValue, bytes, interfaceName, namespace,
 					),
 				)
 			}
 		case "out":
-			if collector.metricFilter.Enabled(interfaceTransmitBytesMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics,
+			if collector.metricFilter.SeriesEnabled(interfaceTransmitBytesMetricName, "device", interfaceName, "namespace", namespace) {
+				*metrics = append(*metrics,
 					prometheus.MustNewConstMetric(
-						collector.interfaceTransmitBytes, prometheus.CounterValue, bytes, interfaceName,
+						collector.interfaceTransmitBytes, prometheus.CounterValue, bytes, interfaceName, namespace,
 					),
 				)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (collector *interfaceCollector) collectInterfaceErrCounters(interfaceName string, counters map[string]string) error {
+func (collector *interfaceCollector) collectInterfaceErrCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) {
 	var interfaceErrorTypeMap = map[string]map[string]string{
 		"in": {
 			"error":   "SAI_PORT_STAT_IF_IN_ERRORS",
@@ -460,65 +778,300 @@ func (collector *interfaceCollector) collectInterfaceErrCounters(interfaceName s
 
 	for _, direction := range []string{"in", "out"} {
 		for errType, key := range interfaceErrorTypeMap[direction] {
-			packets, err := parseFloat(counters[key])
-			if err != nil {
-				return fmt.Errorf("value parse failed: %w", err)
+			packets, ok := parseCounter(parseErrors, counters, key)
+			if !ok {
+				continue
 			}
 
 			switch direction {
 			case "in":
-				if collector.metricFilter.Enabled(interfaceReceiveErrsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics,
+				if collector.metricFilter.SeriesEnabled(interfaceReceiveErrsMetricName, "device", interfaceName, "type", errType, "namespace", namespace) {
+					*metrics = append(*metrics,
 						prometheus.MustNewConstMetric(
-							collector.interfaceReceiveErrs, prometheus.CounterValue, packets, interfaceName, errType,
+							collector.interfaceReceiveErrs, prometheus.CounterValue, packets, interfaceName, errType, namespace,
 						),
 					)
 				}
 			case "out":
-				if collector.metricFilter.Enabled(interfaceTransmitErrsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics,
+				if collector.metricFilter.SeriesEnabled(interfaceTransmitErrsMetricName, "device", interfaceName, "type", errType, "namespace", namespace) {
+					*metrics = append(*metrics,
 						prometheus.MustNewConstMetric(
-							collector.interfaceTransmitErrs, prometheus.CounterValue, packets, interfaceName, errType,
+							collector.interfaceTransmitErrs, prometheus.CounterValue, packets, interfaceName, errType, namespace,
 						),
 					)
 				}
 			}
 		}
 	}
-
-	return nil
 }
 
-func (collector *interfaceCollector) collectInterfacePacketCounters(interfaceName string, counters map[string]string) error {
+func (collector *interfaceCollector) collectInterfacePacketCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) {
 	const interfacePacketCountKey = "SAI_PORT_STAT_IF_%s_%s_PKTS"
 
 	for _, direction := range []string{"in", "out"} {
 		for _, method := range []string{"ucast", "broadcast", "multicast"} {
-			packets, err := parseFloat(counters[fmt.Sprintf(interfacePacketCountKey, strings.ToUpper(direction), strings.ToUpper(method))])
-			if err != nil {
-				return fmt.Errorf("value parse failed: %w", err)
+			packets, ok := parseCounter(parseErrors, counters, fmt.Sprintf(interfacePacketCountKey, strings.ToUpper(direction), strings.ToUpper(method)))
+			if !ok {
+				continue
 			}
 
 			switch direction {
 			case "in":
-				if collector.metricFilter.Enabled(interfaceReceivePacketsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics,
+				if collector.metricFilter.SeriesEnabled(interfaceReceivePacketsMetricName, "device", interfaceName, "method", method, "namespace", namespace) {
+					*metrics = append(*metrics,
 						prometheus.MustNewConstMetric(
-							collector.interfaceReceivePackets, prometheus.CounterValue, packets, interfaceName, method,
+							collector.interfaceReceivePackets, prometheus.CounterValue, packets, interfaceName, method, namespace,
 						),
 					)
 				}
 			case "out":
-				if collector.metricFilter.Enabled(interfaceTransmitPacketsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics,
+				if collector.metricFilter.SeriesEnabled(interfaceTransmitPacketsMetricName, "device", interfaceName, "method", method, "namespace", namespace) {
+					*metrics = append(*metrics,
 						prometheus.MustNewConstMetric(
-							collector.interfaceTransmitPackets, prometheus.CounterValue, packets, interfaceName, method,
+							collector.interfaceTransmitPackets, prometheus.CounterValue, packets, interfaceName, method, namespace,
 						),
 					)
 				}
 			}
 		}
 	}
+}
+
+// collectInterfaceFecCounters collects the FEC counters a port has and the
+// pre-FEC and post-FEC BER. The BER is read from the FEC_PRE_BER and
+// FEC_POST_BER fields of rates, SONiC port rates compute them. Only when a
+// field is absent, the BER is computed since the sample of previousSamples
+// like "show interfaces counters fec-stats". It returns the sample for the
+// next refresh.
+func (collector *interfaceCollector) collectInterfaceFecCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, previousSamples map[string]fecSample, interfaceName, namespace string, counters, rates map[string]string, speed float64) fecSample {
+	var fecFrameTypeMap = map[string]string{
+		"correctable":   "SAI_PORT_STAT_IF_IN_FEC_CORRECTABLE_FRAMES",
+		"uncorrectable": fecNotCorrectableFramesKey,
+	}
+
+	sample := fecSample{time: time.Now()}
+
+	for frameType, key := range fecFrameTypeMap {
+		if _, ok := counters[key]; !ok {
+			continue
+		}
+		frames, ok := parseCounter(parseErrors, counters, key)
+		if !ok {
+			continue
+		}
+
+		if key == fecNotCorrectableFramesKey {
+			sample.uncorrectableFrames, sample.hasUncorrectableFrames = frames, true
+		}
+
+		if collector.metricFilter.SeriesEnabled(interfaceFecFramesMetricName, "device", interfaceName, "type", frameType, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.fecFrames, prometheus.CounterValue, frames, interfaceName, frameType, namespace,
+			))
+		}
+	}
+
+	if _, ok := counters["SAI_PORT_STAT_IF_IN_FEC_SYMBOL_ERRORS"]; ok {
+		symbolErrors, ok := parseCounter(parseErrors, counters, "SAI_PORT_STAT_IF_IN_FEC_SYMBOL_ERRORS")
+		if ok && collector.metricFilter.SeriesEnabled(interfaceFecSymbolErrorsMetricName, "device", interfaceName, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.fecSymbolErrors, prometheus.CounterValue, symbolErrors, interfaceName, namespace,
+			))
+		}
+	}
+
+	if _, ok := counters[fecCorrectedBitsKey]; ok {
+		correctedBits, ok := parseCounter(parseErrors, counters, fecCorrectedBitsKey)
+		if ok {
+			sample.correctedBits, sample.hasCorrectedBits = correctedBits, true
+		}
+		if ok && collector.metricFilter.SeriesEnabled(interfaceFecCorrectedBitsMetricName, "device", interfaceName, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.fecCorrectedBits, prometheus.CounterValue, correctedBits, interfaceName, namespace,
+			))
+		}
+	}
+
+	for key := range counters {
+		match := fecCodewordErrorsRegex.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		codewords, ok := parseCounter(parseErrors, counters, key)
+		if !ok {
+			continue
+		}
+
+		if collector.metricFilter.SeriesEnabled(interfaceFecCodewordErrorsMetricName, "device", interfaceName, "bin", match[1], "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.fecCodewordErrors, prometheus.CounterValue, codewords, interfaceName, match[1], namespace,
+			))
+		}
+	}
+
+	// Bits received on the line since the previous refresh, 0 when unknown
+	lineBits := 0.0
+	previous, ok := previousSamples[interfaceName]
+	if ok && speed > 0 {
+		lineBits = speed * 1000 * 1000 * sample.time.Sub(previous.time).Seconds()
+	}
+
+	var preBer, postBer float64
+	var hasPreBer, hasPostBer bool
+
+	if _, ok := rates[fecPreBerKey]; ok {
+		preBer, hasPreBer = parseCounter(parseErrors, rates, fecPreBerKey)
+	} else if lineBits > 0 && sample.hasCorrectedBits && previous.hasCorrectedBits && sample.correctedBits >= previous.correctedBits {
+		preBer, hasPreBer = (sample.correctedBits-previous.correctedBits)/lineBits, true
+	}
+
+	if _, ok := rates[fecPostBerKey]; ok {
+		postBer, hasPostBer = parseCounter(parseErrors, rates, fecPostBerKey)
+	} else if lineBits > 0 && sample.hasUncorrectableFrames && previous.hasUncorrectableFrames && sample.uncorrectableFrames >= previous.uncorrectableFrames {
+		postBer, hasPostBer = (sample.uncorrectableFrames-previous.uncorrectableFrames)*fecCodewordBits/lineBits, true
+	}
+
+	if hasPreBer && collector.metricFilter.SeriesEnabled(interfaceFecPreBerMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(collector.fecPreBer, prometheus.GaugeValue, preBer, interfaceName, namespace))
+	}
+
+	if hasPostBer && collector.metricFilter.SeriesEnabled(interfaceFecPostBerMetricName, "device", interfaceName, "namespace", namespace) {
+		*metrics = append(*metrics, prometheus.MustNewConstMetric(collector.fecPostBer, prometheus.GaugeValue, postBer, interfaceName, namespace))
+	}
+
+	return sample
+}
+
+// collectInterfacePfcCounters collects the per-priority PFC counters a port
+// has when INTERFACE_PFC_ENABLED is set.
+func (collector *interfaceCollector) collectInterfacePfcCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) {
+	const (
+		pfcFramesKey        = "SAI_PORT_STAT_PFC_%d_%s_PKTS"
+		pfcPauseDurationKey = "SAI_PORT_STAT_PFC_%d_%s_PAUSE_DURATION_US"
+	)
+
+	if !collector.config.pfcEnabled {
+		return
+	}
+
+	for priority := 0; priority < 8; priority++ {
+		for _, direction := range []string{"rx", "tx"} {
+			key := fmt.Sprintf(pfcFramesKey, priority, strings.ToUpper(direction))
+			if _, ok := counters[key]; ok {
+				frames, ok := parseCounter(parseErrors, counters, key)
+				if ok && collector.metricFilter.SeriesEnabled(interfacePfcFramesMetricName, "device", interfaceName, "priority", strconv.Itoa(priority), "direction", direction, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.pfcFrames, prometheus.CounterValue, frames, interfaceName, strconv.Itoa(priority), direction, namespace,
+					))
+				}
+			}
+
+			key = fmt.Sprintf(pfcPauseDurationKey, priority, strings.ToUpper(direction))
+			if _, ok := counters[key]; ok {
+				duration, ok := parseCounter(parseErrors, counters, key)
+				if ok && collector.metricFilter.SeriesEnabled(interfacePfcPauseDurationMetricName, "device", interfaceName, "priority", strconv.Itoa(priority), "direction", direction, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.pfcPauseDuration, prometheus.CounterValue, duration/1000/1000, interfaceName, strconv.Itoa(priority), direction, namespace,
+					))
+				}
+			}
+		}
+	}
+}
+
+// ratesEnabled reports whether any family built from RATES:<oid> is enabled,
+// so the read can be skipped.
+func (collector *interfaceCollector) ratesEnabled() bool {
+	for _, metricName := range []string{
+		interfaceReceiveBitsPerSecondMetricName,
+		interfaceTransmitBitsPerSecondMetricName,
+		interfaceReceivePacketsPerSecondMetricName,
+		interfaceTransmitPacketsPerSecondMetricName,
+		interfaceUtilizationMetricName,
+		interfaceFecPreBerMetricName,
+		interfaceFecPostBerMetricName,
+	} {
+		if collector.metricFilter.Enabled(metricName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectInterfaceRates collects the rates SONiC port_rates.lua keeps in
+// COUNTERS_DB RATES:<oid>. RX_BPS and TX_BPS are in bytes per second. The
+// utilization is computed against speed in Mbit/s, or taken from
+// RX_UTIL/TX_UTIL in percent when the speed is unknown. Ports without rates
+// export no rate series.
+func (collector *interfaceCollector) collectInterfaceRates(metrics *[]prometheus.Metric, parseErrors map[string]float64, rates map[string]string, interfaceName, namespace string, speed float64) {
+	for _, direction := range []string{"rx", "tx"} {
+		prefix := strings.ToUpper(direction)
+		bitsDesc, bitsMetricName := collector.receiveBitsPerSecond, interfaceReceiveBitsPerSecondMetricName
+		packetsDesc, packetsMetricName := collector.receivePacketsPerSecond, interfaceReceivePacketsPerSecondMetricName
+		if direction == "tx" {
+			bitsDesc, bitsMetricName = collector.transmitBitsPerSecond, interfaceTransmitBitsPerSecondMetricName
+			packetsDesc, packetsMetricName = collector.transmitPacketsPerSecond, interfaceTransmitPacketsPerSecondMetricName
+		}
+
+		if _, ok := rates[prefix+"_BPS"]; ok {
+			bytesPerSecond, ok := parseCounter(parseErrors, rates, prefix+"_BPS")
+			if ok {
+				if collector.metricFilter.SeriesEnabled(bitsMetricName, "device", interfaceName, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(bitsDesc, prometheus.GaugeValue, bytesPerSecond*8, interfaceName, namespace))
+				}
+
+				if speed > 0 && collector.metricFilter.SeriesEnabled(interfaceUtilizationMetricName, "device", interfaceName, "direction", direction, "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.utilization, prometheus.GaugeValue, bytesPerSecond*8/(speed*1000*1000), interfaceName, direction, namespace,
+					))
+				}
+			}
+		}
+
+		if _, ok := rates[prefix+"_UTIL"]; ok && speed <= 0 {
+			utilization, ok := parseCounter(parseErrors, rates, prefix+"_UTIL")
+			if ok && collector.metricFilter.SeriesEnabled(interfaceUtilizationMetricName, "device", interfaceName, "direction", direction, "namespace", namespace) {
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					collector.utilization, prometheus.GaugeValue, utilization/100, interfaceName, direction, namespace,
+				))
+			}
+		}
+
+		if _, ok := rates[prefix+"_PPS"]; ok {
+			packetsPerSecond, ok := parseCounter(parseErrors, rates, prefix+"_PPS")
+			if ok && collector.metricFilter.SeriesEnabled(packetsMetricName, "device", interfaceName, "namespace", namespace) {
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(packetsDesc, prometheus.GaugeValue, packetsPerSecond, interfaceName, namespace))
+			}
+		}
+	}
+}
+
+// collectInterfaceRateConfig collects the smoothing settings of SONiC port
+// rates from COUNTERS_DB RATES:PORT, when present.
+func (collector *interfaceCollector) collectInterfaceRateConfig(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, parseErrors map[string]float64, namespace string) error {
+	if !collector.metricFilter.Enabled(interfaceRateSmoothingAlphaMetricName) && !collector.metricFilter.Enabled(interfaceRateSmoothingIntervalMetricName) {
+		return nil
+	}
+
+	rateConfig, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "RATES:PORT")
+	if err != nil {
+		return fmt.Errorf("redis read failed: %w", err)
+	}
+
+	if _, ok := rateConfig["PORT_ALPHA"]; ok {
+		alpha, ok := parseCounter(parseErrors, rateConfig, "PORT_ALPHA")
+		if ok && collector.metricFilter.SeriesEnabled(interfaceRateSmoothingAlphaMetricName, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(collector.rateSmoothingAlpha, prometheus.GaugeValue, alpha, namespace))
+		}
+	}
+
+	if _, ok := rateConfig["PORT_SMOOTH_INTERVAL"]; ok {
+		interval, ok := parseCounter(parseErrors, rateConfig, "PORT_SMOOTH_INTERVAL")
+		if ok && collector.metricFilter.SeriesEnabled(interfaceRateSmoothingIntervalMetricName, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(collector.rateSmoothingInterval, prometheus.GaugeValue, interval, namespace))
+		}
+	}
 
 	return nil
 }
@@ -552,32 +1105,41 @@ func (p packetSize) format(direction string) string {
 	return ""
 }
 
-func (collector *interfaceCollector) collectInterfacePacketSizeCounters(interfaceName string, counters map[string]string) error {
+func (collector *interfaceCollector) collectInterfacePacketSizeCounters(metrics *[]prometheus.Metric, parseErrors map[string]float64, interfaceName, namespace string, counters map[string]string) {
 	var sizes = []packetSize{"64", "127", "255", "511", "1023", "1518", "2047", "4095", "9216", "16383"}
 
 	for _, direction := range []string{"in", "out"} {
 		for _, size := range sizes {
-			bytes, err := parseFloat(counters[size.format(direction)])
-			if err != nil {
-				return fmt.Errorf("value parse failed: %w", err)
+			bytes, ok := parseCounter(parseErrors, counters, size.format(direction))
+			if !ok {
+				continue
 			}
 
 			switch direction {
 			case "in":
-				if collector.metricFilter.Enabled(interfaceReceiveEthernetPacketsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceReceiveEthernetPackets, prometheus.CounterValue, bytes, interfaceName, string(size),
+				if collector.metricFilter.SeriesEnabled(interfaceReceiveEthernetPacketsMetricName, "device", interfaceName, "size", string(size), "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceReceiveEthernetPackets, prometheus.CounterValue, bytes, interfaceName, string(size), namespace,
 					))
 				}
 			case "out":
-				if collector.metricFilter.Enabled(interfaceTransmitEthernetPacketsMetricName) {
-					collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-						collector.interfaceTransmitEthernetPackets, prometheus.CounterValue, bytes, interfaceName, string(size),
+				if collector.metricFilter.SeriesEnabled(interfaceTransmitEthernetPacketsMetricName, "device", interfaceName, "size", string(size), "namespace", namespace) {
+					*metrics = append(*metrics, prometheus.MustNewConstMetric(
+						collector.interfaceTransmitEthernetPackets, prometheus.CounterValue, bytes, interfaceName, string(size), namespace,
 					))
 				}
 			}
 		}
 	}
+}
 
-	return nil
+func loadInterfaceCollectorConfig(logger *slog.Logger) interfaceCollectorConfig {
+	return interfaceCollectorConfig{
+		enabled:         parseBoolEnv(logger, "INTERFACE_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "INTERFACE_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "INTERFACE_TIMEOUT", 5*time.Second),
+		saiStats:        parseMetricPatternsEnv(logger, "INTERFACE_SAI_STATS"),
+		maxSaiStats:     parseIntEnv(logger, "INTERFACE_MAX_SAI_STATS", 64),
+		pfcEnabled:      parseBoolEnv(logger, "INTERFACE_PFC_ENABLED", false),
+	}
 }
@@ -0,0 +1,237 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// labelRule drops series of matching metric families by label value, e.g.
+// drop sonic_queue_*{device=~"Ethernet(1[0-9]{2})"} or
+// keep sonic_queue_*{queue=~"[0-7]"}.
+type labelRule struct {
+	keep     bool
+	pattern  string
+	matchers []labelMatcher
+}
+
+type labelMatcher struct {
+	label  string
+	negate bool
+	// regexp is nil for equality matchers
+	regexp *regexp.Regexp
+	value  string
+}
+
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+
+// parseLabelRules reads semicolon separated rules from key. An invalid rule
+// is an error.
+func parseLabelRules(key string) ([]labelRule, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var rules []labelRule
+	for _, token := range strings.Split(value, ";") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		rule, err := parseLabelRule(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric label rule %q in %s: %w", token, key, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func parseLabelRule(value string) (labelRule, error) {
+	action, selector, ok := strings.Cut(value, " ")
+	if !ok {
+		return labelRule{}, errors.New("expected <keep|drop> <metric pattern>{<matchers>}")
+	}
+
+	rule := labelRule{}
+	switch action {
+	case "keep":
+		rule.keep = true
+	case "drop":
+	default:
+		return labelRule{}, fmt.Errorf("unknown action %q, must be keep or drop", action)
+	}
+
+	selector = strings.TrimSpace(selector)
+	pattern, matchers, ok := strings.Cut(selector, "{")
+	if !ok || !strings.HasSuffix(matchers, "}") {
+		return labelRule{}, errors.New("missing {<matchers>}")
+	}
+
+	rule.pattern = strings.TrimSpace(pattern)
+	if rule.pattern == "" {
+		return labelRule{}, errors.New("missing metric pattern")
+	}
+	if _, err := path.Match(rule.pattern, ""); err != nil {
+		return labelRule{}, fmt.Errorf("invalid metric pattern: %w", err)
+	}
+
+	var err error
+	rule.matchers, err = parseLabelMatchers(strings.TrimSuffix(matchers, "}"))
+	if err != nil {
+		return labelRule{}, err
+	}
+
+	return rule, nil
+}
+
+// parseLabelMatchers parses label="value", label!="value", label=~"regex"
+// and label!~"regex" separated by commas.
+func parseLabelMatchers(value string) ([]labelMatcher, error) {
+	var matchers []labelMatcher
+
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		label := labelNamePattern.FindString(rest)
+		if label == "" {
+			return nil, fmt.Errorf("invalid label name at %q", rest)
+		}
+		rest = strings.TrimLeftFunc(rest[len(label):], unicode.IsSpace)
+
+		matcher := labelMatcher{label: label}
+		isRegexp := false
+		switch {
+		case strings.HasPrefix(rest, "=~"):
+			isRegexp = true
+		case strings.HasPrefix(rest, "!~"):
+			isRegexp, matcher.negate = true, true
+		case strings.HasPrefix(rest, "!="):
+			matcher.negate = true
+		case strings.HasPrefix(rest, "="):
+		default:
+			return nil, fmt.Errorf("missing operator after label %s", label)
+		}
+		if isRegexp || matcher.negate {
+			rest = rest[2:]
+		} else {
+			rest = rest[1:]
+		}
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("value of label %s must be quoted", label)
+		}
+		matcher.value, err = strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of label %s: %w", label, err)
+		}
+		if isRegexp {
+			matcher.regexp, err = regexp.Compile("^(?:" + matcher.value + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regexp of label %s: %w", label, err)
+			}
+		}
+		matchers = append(matchers, matcher)
+
+		rest = strings.TrimLeftFunc(rest[len(quoted):], unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, ",") {
+			return nil, fmt.Errorf("expected , after label %s", label)
+		}
+		rest = strings.TrimLeftFunc(rest[1:], unicode.IsSpace)
+	}
+
+	if len(matchers) == 0 {
+		return nil, errors.New("at least one label matcher is required")
+	}
+
+	return matchers, nil
+}
+
+func (matcher labelMatcher) matches(labels []string) bool {
+	// A missing label has an empty value, like in PromQL
+	value := ""
+	for i := 0; i+1 < len(labels); i += 2 {
+		if labels[i] == matcher.label {
+			value = labels[i+1]
+			break
+		}
+	}
+
+	var matched bool
+	if matcher.regexp != nil {
+		matched = matcher.regexp.MatchString(value)
+	} else {
+		matched = value == matcher.value
+	}
+
+	return matched != matcher.negate
+}
+
+// drops reports whether the rule drops a series of metricName with labels.
+// Drop rules drop series matching every matcher, keep rules drop the others.
+func (rule labelRule) drops(metricName string, labels []string) bool {
+	if matched, err := path.Match(rule.pattern, metricName); err != nil || !matched {
+		return false
+	}
+
+	matched := true
+	for _, matcher := range rule.matchers {
+		if !matcher.matches(labels) {
+			matched = false
+			break
+		}
+	}
+
+	return matched != rule.keep
+}
+
+// filteredSeries counts series dropped by label rules per metric family.
+type filteredSeries struct {
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+func (filtered *filteredSeries) add(metricName string) {
+	if filtered == nil {
+		return
+	}
+
+	filtered.mu.Lock()
+	filtered.counts[metricName]++
+	filtered.mu.Unlock()
+}
+
+// snapshot returns metric family names in sorted order and their counts.
+func (filtered *filteredSeries) snapshot() ([]string, map[string]float64) {
+	if filtered == nil {
+		return nil, nil
+	}
+
+	filtered.mu.Lock()
+	defer filtered.mu.Unlock()
+
+	counts := make(map[string]float64, len(filtered.counts))
+	names := make([]string, 0, len(filtered.counts))
+	for name, count := range filtered.counts {
+		counts[name] = count
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, counts
+}
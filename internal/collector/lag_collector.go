@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"sort"
 	"strings"
 	"sync"
@@ -13,6 +14,15 @@ import (
 	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
+const (
+	lagReceiveBytesMetricName    = "sonic_lag_receive_bytes_total"
+	lagTransmitBytesMetricName   = "sonic_lag_transmit_bytes_total"
+	lagReceivePacketsMetricName  = "sonic_lag_receive_packets_total"
+	lagTransmitPacketsMetricName = "sonic_lag_transmit_packets_total"
+	lagReceiveErrorsMetricName   = "sonic_lag_receive_errors_total"
+	lagTransmitErrorsMetricName  = "sonic_lag_transmit_errors_total"
+)
+
 type lagCollectorConfig struct {
 	enabled         bool
 	refreshInterval time.Duration
@@ -32,17 +42,23 @@ type lagCollector struct {
 	scrapeCollectorSuccess *prometheus.Desc
 	cacheAge               *prometheus.Desc
 	skippedEntries         *prometheus.Desc
+	counters               []lagCounter
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       lagCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
-	cachedMetrics      []prometheus.Metric
-	lastSuccess        float64
+	snapshots          map[string]namespaceSnapshot
 	lastScrapeDuration float64
-	lastSkippedEntries float64
-	lastRefreshTime    time.Time
+
+	// counterStates holds the aggregate counters per namespace and LAG. It is
+	// updated by refreshMetrics and read once by the collector replacing this
+	// one on reload, countersMu guards it.
+	countersMu    sync.Mutex
+	counterStates map[string]map[string]lagCounterState
 }
 
 type lagMemberEntry struct {
@@ -50,7 +66,33 @@ type lagMemberEntry struct {
 	status string
 }
 
-func NewLagCollector(logger *slog.Logger, metricFilter MetricFilter) *lagCollector {
+// lagCounter is an aggregate LAG counter, the sum of the given port counters
+// of every member.
+type lagCounter struct {
+	metricName string
+	keys       []string
+	desc       *prometheus.Desc
+}
+
+// lagCounterState is the aggregate counter state of a LAG at the previous
+// refresh. totals only grow by member deltas, so a member joining or leaving
+// does not reset the LAG counters.
+type lagCounterState struct {
+	totals  []float64
+	members map[string][]float64
+}
+
+func NewLagCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *lagCollector {
+	return newLagCollector(logger, metricFilter, redisClient, loadLagCollectorConfig(logger), nil)
+}
+
+// newLagCollector continues the LAG counters from counterStates, which may be
+// nil to start from the member sums.
+func newLagCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config lagCollectorConfig, counterStates map[string]map[string]lagCounterState) *lagCollector {
+	if counterStates == nil {
+		counterStates = map[string]map[string]lagCounterState{}
+	}
+
 	const (
 		namespace = "sonic"
 		subsystem = "lag"
@@ -58,26 +100,47 @@ func NewLagCollector(logger *slog.Logger, metricFilter MetricFilter) *lagCollect
 
 	collector := &lagCollector{
 		lagInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "info"),
-			"Non-numeric data about LAG, value is always 1", []string{"lag"}, nil),
+			"Non-numeric data about LAG, value is always 1", []string{"lag", "namespace"}, nil),
 		lagAdminStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "admin_status"),
-			"Administrative state of LAG (1=up, 0=down)", []string{"lag"}, nil),
+			"Administrative state of LAG (1=up, 0=down)", []string{"lag", "namespace"}, nil),
 		lagOperStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "oper_status"),
-			"Operational state of LAG (1=up, 0=down)", []string{"lag"}, nil),
+			"Operational state of LAG (1=up, 0=down)", []string{"lag", "namespace"}, nil),
 		lagMembers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "members"),
-			"Number of LAG member interfaces", []string{"lag"}, nil),
+			"Number of LAG member interfaces", []string{"lag", "namespace"}, nil),
 		lagMemberStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "member_status"),
-			"Status of LAG member interface (1=enabled, 0=disabled)", []string{"lag", "member"}, nil),
+			"Status of LAG member interface (1=enabled, 0=disabled)", []string{"lag", "member", "namespace"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
 			"Time it took for exporter to refresh LAG metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether LAG collector succeeded", nil, nil),
+			"Whether LAG collector succeeded", []string{"namespace"}, nil),
 		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
 			"Age of latest LAG cache refresh", nil, nil),
 		skippedEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
 			"Number of LAG entries skipped during latest refresh", nil, nil),
-		logger:       logger,
-		metricFilter: metricFilter,
-		config:       loadLagCollectorConfig(logger),
+		logger:        logger,
+		metricFilter:  metricFilter,
+		redisClient:   redisClient,
+		config:        config,
+		stop:          make(chan struct{}),
+		counterStates: counterStates,
+	}
+
+	counterDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, name), help, []string{"lag", "namespace"}, nil)
+	}
+	collector.counters = []lagCounter{
+		{lagReceiveBytesMetricName, []string{"SAI_PORT_STAT_IF_IN_OCTETS"},
+			counterDesc("receive_bytes_total", "Number of bytes received on LAG member interfaces")},
+		{lagTransmitBytesMetricName, []string{"SAI_PORT_STAT_IF_OUT_OCTETS"},
+			counterDesc("transmit_bytes_total", "Number of bytes transmitted on LAG member interfaces")},
+		{lagReceivePacketsMetricName, []string{"SAI_PORT_STAT_IF_IN_UCAST_PKTS", "SAI_PORT_STAT_IF_IN_BROADCAST_PKTS", "SAI_PORT_STAT_IF_IN_MULTICAST_PKTS"},
+			counterDesc("receive_packets_total", "Number of packets received on LAG member interfaces")},
+		{lagTransmitPacketsMetricName, []string{"SAI_PORT_STAT_IF_OUT_UCAST_PKTS", "SAI_PORT_STAT_IF_OUT_BROADCAST_PKTS", "SAI_PORT_STAT_IF_OUT_MULTICAST_PKTS"},
+			counterDesc("transmit_packets_total", "Number of packets transmitted on LAG member interfaces")},
+		{lagReceiveErrorsMetricName, []string{"SAI_PORT_STAT_IF_IN_ERRORS"},
+			counterDesc("receive_errors_total", "Number of receive errors on LAG member interfaces")},
+		{lagTransmitErrorsMetricName, []string{"SAI_PORT_STAT_IF_OUT_ERRORS"},
+			counterDesc("transmit_errors_total", "Number of transmit errors on LAG member interfaces")},
 	}
 
 	if !collector.config.enabled {
@@ -91,16 +154,52 @@ func NewLagCollector(logger *slog.Logger, metricFilter MetricFilter) *lagCollect
 	return collector
 }
 
+// lagCounterStatesOf returns a copy of the counter states of the LAG
+// collector in collectors, nil when there is none.
+func lagCounterStatesOf(collectors []SonicCollector) map[string]map[string]lagCounterState {
+	for _, collector := range collectors {
+		previous, ok := collector.(*lagCollector)
+		if !ok {
+			continue
+		}
+
+		previous.countersMu.Lock()
+		defer previous.countersMu.Unlock()
+
+		return maps.Clone(previous.counterStates)
+	}
+
+	return nil
+}
+
 func (collector *lagCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *lagCollector) Name() string {
+	return "lag"
+}
+
+func (collector *lagCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *lagCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *lagCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.lagInfo
 	ch <- collector.lagAdminStatus
 	ch <- collector.lagOperStatus
 	ch <- collector.lagMembers
 	ch <- collector.lagMemberStatus
+	for _, counter := range collector.counters {
+		ch <- counter.desc
+	}
 	ch <- collector.scrapeDuration
 	ch <- collector.scrapeCollectorSuccess
 	ch <- collector.cacheAge
@@ -113,28 +212,24 @@ func (collector *lagCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	collector.mu.RLock()
-	cachedMetrics := append([]prometheus.Metric{}, collector.cachedMetrics...)
-	lastSkippedEntries := collector.lastSkippedEntries
+	snapshots := collector.snapshots
 	lastScrapeDuration := collector.lastScrapeDuration
-	lastSuccess := collector.lastSuccess
-	lastRefreshTime := collector.lastRefreshTime
 	collector.mu.RUnlock()
 
-	for _, metric := range cachedMetrics {
-		ch <- metric
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled("sonic_lag_collector_success") {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	cacheAge := 0.0
-	if !lastRefreshTime.IsZero() {
-		cacheAge = time.Since(lastRefreshTime).Seconds()
-	}
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
 
 	if collector.metricFilter.Enabled("sonic_lag_scrape_duration_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled("sonic_lag_collector_success") {
-		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
-	}
 	if collector.metricFilter.Enabled("sonic_lag_cache_age_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
@@ -147,43 +242,37 @@ func (collector *lagCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
 func (collector *lagCollector) refreshMetrics() {
 	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
-	defer cancel()
 
-	metrics, skippedEntries, err := collector.scrapeMetrics(ctx)
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "LAG", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
 	scrapeDuration := time.Since(start).Seconds()
 
 	collector.mu.Lock()
 	defer collector.mu.Unlock()
 
 	collector.lastScrapeDuration = scrapeDuration
-
-	if err != nil {
-		collector.lastSuccess = 0
-		collector.logger.Error("Error refreshing LAG metrics", "error", err)
-		return
-	}
-
-	collector.cachedMetrics = metrics
-	collector.lastSkippedEntries = float64(skippedEntries)
-	collector.lastSuccess = 1
-	collector.lastRefreshTime = time.Now()
+	collector.snapshots = snapshots
 }
 
-func (collector *lagCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
-
+func (collector *lagCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
 	configLags, err := redisClient.ScanKeysFromDb(ctx, "CONFIG_DB", "PORTCHANNEL|*", collector.config.redisScanCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to scan PORTCHANNEL keys: %w", err)
@@ -249,11 +338,15 @@ func (collector *lagCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 	}
 
 	metrics := make([]prometheus.Metric, 0)
+	countedLags := []string{}
+	countedMembers := map[string][]string{}
+	cutLags := []string{}
 	processedLags := 0
 	processedMembers := 0
 
 	for _, lagName := range lagNames {
 		if processedLags >= collector.config.maxLags {
+			cutLags = append(cutLags, lagName)
 			skippedEntries++
 			continue
 		}
@@ -269,16 +362,16 @@ func (collector *lagCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 		}
 
 		if collector.metricFilter.Enabled("sonic_lag_info") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagInfo, prometheus.GaugeValue, 1, lagName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagInfo, prometheus.GaugeValue, 1, lagName, namespace))
 		}
 
 		adminStatus := firstNonEmpty(applData["admin_status"], configData["admin_status"])
 		if adminStatus != "" && collector.metricFilter.Enabled("sonic_lag_admin_status") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagAdminStatus, prometheus.GaugeValue, statusToGauge(adminStatus), lagName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagAdminStatus, prometheus.GaugeValue, statusToGauge(adminStatus), lagName, namespace))
 		}
 
 		if operStatus := applData["oper_status"]; operStatus != "" && collector.metricFilter.Enabled("sonic_lag_oper_status") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagOperStatus, prometheus.GaugeValue, statusToGauge(operStatus), lagName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagOperStatus, prometheus.GaugeValue, statusToGauge(operStatus), lagName, namespace))
 		}
 
 		members := membersByLag[lagName]
@@ -300,22 +393,163 @@ func (collector *lagCollector) scrapeMetrics(ctx context.Context) ([]prometheus.
 					statusToGauge(member.status),
 					lagName,
 					member.name,
+					namespace,
 				))
 			}
 
+			countedMembers[lagName] = append(countedMembers[lagName], member.name)
 			processedMembers++
 			memberCount++
 		}
 
 		if collector.metricFilter.Enabled("sonic_lag_members") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagMembers, prometheus.GaugeValue, float64(memberCount), lagName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.lagMembers, prometheus.GaugeValue, float64(memberCount), lagName, namespace))
 		}
+		countedLags = append(countedLags, lagName)
 		processedLags++
 	}
 
+	if collector.countersEnabled() {
+		counterMetrics, skippedMembers, err := collector.collectLagCounters(ctx, redisClient, namespace, countedLags, countedMembers, cutLags)
+		if err != nil {
+			return nil, 0, err
+		}
+		metrics = append(metrics, counterMetrics...)
+		skippedEntries += skippedMembers
+	}
+
 	return metrics, skippedEntries, nil
 }
 
+func (collector *lagCollector) countersEnabled() bool {
+	for _, counter := range collector.counters {
+		if collector.metricFilter.Enabled(counter.metricName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectLagCounters sums the port counters of LAG members, since PortChannels
+// have no COUNTERS_DB entry of their own. A LAG starts from the sum of its
+// member counters, after that only member deltas are added: a joining member
+// adds nothing until its next refresh and a leaving member keeps what it
+// added. A member whose counter decreased was cleared, its new value is the
+// delta. A member without counters or with an unparsable counter keeps its
+// previous sample, unparsable ones are counted as skipped. cutLags, the LAGs
+// cut by LAG_MAX_LAGS, keep their state to continue once they fit again.
+func (collector *lagCollector) collectLagCounters(ctx context.Context, redisClient redis.Client, namespace string, lagNames []string, membersByLag map[string][]string, cutLags []string) ([]prometheus.Metric, int, error) {
+	ports, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_PORT_NAME_MAP")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read COUNTERS_PORT_NAME_MAP: %w", err)
+	}
+
+	counterKeys := []string{}
+	for _, lagName := range lagNames {
+		for _, member := range membersByLag[lagName] {
+			if oid, ok := ports[member]; ok {
+				counterKeys = append(counterKeys, "COUNTERS:"+oid)
+			}
+		}
+	}
+
+	counterData, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", counterKeys)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read LAG member counters: %w", err)
+	}
+
+	collector.countersMu.Lock()
+	previousStates := collector.counterStates[namespace]
+	collector.countersMu.Unlock()
+
+	metrics := make([]prometheus.Metric, 0)
+	skippedEntries := 0
+	states := make(map[string]lagCounterState, len(lagNames)+len(cutLags))
+	next := 0
+
+	for _, lagName := range cutLags {
+		if previous, ok := previousStates[lagName]; ok {
+			states[lagName] = previous
+		}
+	}
+
+	for _, lagName := range lagNames {
+		previous, seen := previousStates[lagName]
+		state := lagCounterState{totals: make([]float64, len(collector.counters)), members: map[string][]float64{}}
+		copy(state.totals, previous.totals)
+
+		for _, member := range membersByLag[lagName] {
+			var counters map[string]string
+			if _, ok := ports[member]; ok {
+				counters = counterData[next]
+				next++
+			}
+
+			values, err := collector.parseLagMemberCounters(counters)
+			if err != nil {
+				collector.logger.Debug("Skipping LAG member counters", "lag", lagName, "member", member, "namespace", namespace, "error", err)
+				skippedEntries++
+			}
+			if err != nil || len(counters) == 0 {
+				if last, ok := previous.members[member]; ok {
+					state.members[member] = last
+				}
+				continue
+			}
+
+			last, wasMember := previous.members[member]
+			for i, value := range values {
+				switch {
+				case !seen:
+					state.totals[i] += value
+				case !wasMember:
+				case value >= last[i]:
+					state.totals[i] += value - last[i]
+				default:
+					state.totals[i] += value
+				}
+			}
+			state.members[member] = values
+		}
+
+		for i, counter := range collector.counters {
+			if collector.metricFilter.SeriesEnabled(counter.metricName, "lag", lagName, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(counter.desc, prometheus.CounterValue, state.totals[i], lagName, namespace))
+			}
+		}
+		states[lagName] = state
+	}
+
+	collector.countersMu.Lock()
+	collector.counterStates[namespace] = states
+	collector.countersMu.Unlock()
+
+	return metrics, skippedEntries, nil
+}
+
+// parseLagMemberCounters returns the value of every LAG counter for one member
+// port. Port counters the member does not have count as zero.
+func (collector *lagCollector) parseLagMemberCounters(counters map[string]string) ([]float64, error) {
+	values := make([]float64, len(collector.counters))
+	for i, counter := range collector.counters {
+		for _, key := range counter.keys {
+			value, ok := counters[key]
+			if !ok {
+				continue
+			}
+
+			parsed, err := parseFloat(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s parse failed: %w", key, err)
+			}
+			values[i] += parsed
+		}
+	}
+
+	return values, nil
+}
+
 func loadLagCollectorConfig(logger *slog.Logger) lagCollectorConfig {
 	return lagCollectorConfig{
 		enabled:         parseBoolEnv(logger, "LAG_ENABLED", true),
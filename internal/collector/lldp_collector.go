@@ -35,7 +35,9 @@ type lldpCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       lldpCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
 	cachedMetrics      []prometheus.Metric
@@ -46,7 +48,11 @@ type lldpCollector struct {
 	lastRefreshTime    time.Time
 }
 
-func NewLldpCollector(logger *slog.Logger, metricFilter MetricFilter) *lldpCollector {
+func NewLldpCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *lldpCollector {
+	return newLldpCollector(logger, metricFilter, redisClient, loadLldpCollectorConfig(logger))
+}
+
+func newLldpCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config lldpCollectorConfig) *lldpCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "lldp"
@@ -69,7 +75,9 @@ func NewLldpCollector(logger *slog.Logger, metricFilter MetricFilter) *lldpColle
 			"Number of LLDP entries skipped during latest refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config:       loadLldpCollectorConfig(logger),
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -87,6 +95,21 @@ func (collector *lldpCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *lldpCollector) Name() string {
+	return "lldp"
+}
+
+func (collector *lldpCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *lldpCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *lldpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.lldpNeighborInfo
 	ch <- collector.lldpLocalChassisInfo
@@ -141,8 +164,13 @@ func (collector *lldpCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -173,11 +201,7 @@ func (collector *lldpCollector) refreshMetrics() {
 }
 
 func (collector *lldpCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, int, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	lldpKeys, err := redisClient.ScanKeysFromDb(ctx, "APPL_DB", "LLDP_ENTRY_TABLE:*", collector.config.redisScanCount)
 	if err != nil {
@@ -250,7 +274,17 @@ func (collector *lldpCollector) scrapeMetrics(ctx context.Context) ([]prometheus
 			localRole = "other"
 		}
 
-		if collector.metricFilter.Enabled("sonic_lldp_neighbor_info") {
+		if collector.metricFilter.SeriesEnabled("sonic_lldp_neighbor_info",
+			"local_interface", localInterface,
+			"local_role", localRole,
+			"remote_system_name", remoteSystemName,
+			"remote_port_id", remotePortID,
+			"remote_port_desc", remotePortDesc,
+			"remote_port_id_subtype", remotePortIDSubtype,
+			"remote_port_display", remotePortDisplay,
+			"remote_chassis_id", remoteChassisID,
+			"remote_mgmt_ip", remoteMgmtIP,
+		) {
 			metrics = append(metrics, prometheus.MustNewConstMetric(
 				collector.lldpNeighborInfo, prometheus.GaugeValue, 1,
 				localInterface,
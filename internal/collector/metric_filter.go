@@ -1,33 +1,81 @@
 package collector
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"path"
+	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// MetricFilter disables metrics based on SONIC_DISABLED_METRICS.
+// healthMetricSuffixes mark collector health families, which stay enabled in
+// allowlist mode unless SONIC_DISABLED_METRICS matches them.
+var healthMetricSuffixes = []string{"_collector_success", "_scrape_duration_seconds"}
+
+// MetricFilter disables metrics based on SONIC_DISABLED_METRICS and
+// SONIC_ENABLED_METRICS.
+//
+// When SONIC_ENABLED_METRICS is set, only matching families and health
+// families are enabled. SONIC_DISABLED_METRICS is applied afterwards and
+// always wins.
+//
+// Series are also filtered by SONIC_METRIC_LABEL_RULES, see SeriesEnabled.
 type MetricFilter struct {
-	logger   *slog.Logger
+	logger     *slog.Logger
+	disabled   metricPatterns
+	enabled    metricPatterns
+	labelRules []labelRule
+	filtered   *filteredSeries
+}
+
+// metricPatterns is a list of full metric names and wildcard patterns.
+type metricPatterns struct {
 	exact    map[string]struct{}
 	patterns []string
 }
 
-// NewMetricFilter builds a MetricFilter from SONIC_DISABLED_METRICS.
-func NewMetricFilter(logger *slog.Logger) MetricFilter {
+// NewMetricFilter builds a MetricFilter from SONIC_DISABLED_METRICS,
+// SONIC_ENABLED_METRICS and SONIC_METRIC_LABEL_RULES. An invalid pattern or
+// rule is an error, so a typo does not export series meant to be filtered.
+func NewMetricFilter(logger *slog.Logger) (MetricFilter, error) {
 	if logger == nil {
 		logger = slog.Default()
 	}
 
-	filter := MetricFilter{
-		logger: logger,
-		exact:  make(map[string]struct{}),
+	disabled, err := parseMetricPatterns("SONIC_DISABLED_METRICS")
+	if err != nil {
+		return MetricFilter{}, err
 	}
 
-	value, exists := os.LookupEnv("SONIC_DISABLED_METRICS")
+	enabled, err := parseMetricPatterns("SONIC_ENABLED_METRICS")
+	if err != nil {
+		return MetricFilter{}, err
+	}
+
+	labelRules, err := parseLabelRules("SONIC_METRIC_LABEL_RULES")
+	if err != nil {
+		return MetricFilter{}, err
+	}
+
+	return MetricFilter{
+		logger:     logger,
+		disabled:   disabled,
+		enabled:    enabled,
+		labelRules: labelRules,
+		filtered:   &filteredSeries{counts: map[string]float64{}},
+	}, nil
+}
+
+func parseMetricPatterns(key string) (metricPatterns, error) {
+	patterns := metricPatterns{exact: make(map[string]struct{})}
+
+	value, exists := os.LookupEnv(key)
 	if !exists || strings.TrimSpace(value) == "" {
-		return filter
+		return patterns, nil
 	}
 
 	for _, token := range strings.Split(value, ",") {
@@ -38,27 +86,42 @@ func NewMetricFilter(logger *slog.Logger) MetricFilter {
 
 		if isGlobPattern(token) {
 			if _, err := path.Match(token, ""); err != nil {
-				logger.Warn("Ignoring invalid disabled metric pattern", "key", "SONIC_DISABLED_METRICS", "pattern", token, "error", err)
-				continue
+				return metricPatterns{}, fmt.Errorf("invalid metric pattern %q in %s: %w", token, key, err)
 			}
 
-			filter.patterns = append(filter.patterns, token)
+			patterns.patterns = append(patterns.patterns, token)
 			continue
 		}
 
-		filter.exact[token] = struct{}{}
+		patterns.exact[token] = struct{}{}
 	}
 
-	return filter
+	return patterns, nil
 }
 
-// Disabled reports whether metricName is disabled.
-func (filter MetricFilter) Disabled(metricName string) bool {
-	if _, ok := filter.exact[metricName]; ok {
+// parseMetricPatternsEnv is parseMetricPatterns for collector settings, which
+// config.Load has validated already. An invalid value is logged and no
+// pattern is used.
+func parseMetricPatternsEnv(logger *slog.Logger, key string) metricPatterns {
+	patterns, err := parseMetricPatterns(key)
+	if err != nil {
+		logger.Warn("Invalid metric patterns in env, using none", "key", key, "error", err)
+		return metricPatterns{exact: make(map[string]struct{})}
+	}
+
+	return patterns
+}
+
+func (patterns metricPatterns) empty() bool {
+	return len(patterns.exact) == 0 && len(patterns.patterns) == 0
+}
+
+func (patterns metricPatterns) match(metricName string) bool {
+	if _, ok := patterns.exact[metricName]; ok {
 		return true
 	}
 
-	for _, pattern := range filter.patterns {
+	for _, pattern := range patterns.patterns {
 		matched, err := path.Match(pattern, metricName)
 		if err != nil {
 			continue
@@ -71,11 +134,115 @@ func (filter MetricFilter) Disabled(metricName string) bool {
 	return false
 }
 
+// Disabled reports whether metricName is disabled.
+func (filter MetricFilter) Disabled(metricName string) bool {
+	if filter.disabled.match(metricName) {
+		return true
+	}
+
+	if filter.enabled.empty() || isHealthMetric(metricName) {
+		return false
+	}
+
+	return !filter.enabled.match(metricName)
+}
+
 // Enabled reports whether metricName is enabled.
 func (filter MetricFilter) Enabled(metricName string) bool {
 	return !filter.Disabled(metricName)
 }
 
+// SeriesEnabled reports whether a series of metricName is enabled. labels are
+// label name and value pairs of the series, e.g. "device", "Ethernet0".
+// Series dropped by label rules are counted per metric family.
+func (filter MetricFilter) SeriesEnabled(metricName string, labels ...string) bool {
+	if filter.Disabled(metricName) {
+		return false
+	}
+
+	for _, rule := range filter.labelRules {
+		if rule.drops(metricName, labels) {
+			filter.filtered.add(metricName)
+			return false
+		}
+	}
+
+	return true
+}
+
+// Active reports whether any metric is filtered.
+func (filter MetricFilter) Active() bool {
+	return !filter.disabled.empty() || !filter.enabled.empty() || len(filter.labelRules) > 0
+}
+
 func isGlobPattern(value string) bool {
 	return strings.ContainsAny(value, "*?[")
 }
+
+func isHealthMetric(metricName string) bool {
+	for _, suffix := range healthMetricSuffixes {
+		if strings.HasSuffix(metricName, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var descNamePattern = regexp.MustCompile(`fqName: "([^"]+)"`)
+
+// logActiveMetrics logs the enabled and disabled metric families of every
+// collector when a filter is configured.
+func logActiveMetrics(logger *slog.Logger, filter MetricFilter, collectors []SonicCollector) {
+	if !filter.Active() {
+		return
+	}
+
+	for _, collector := range collectors {
+		var active, disabled []string
+		for _, name := range describedMetricNames(collector) {
+			if filter.Enabled(name) {
+				active = append(active, name)
+			} else {
+				disabled = append(disabled, name)
+			}
+		}
+
+		logger.Info("Metric families filtered", "collector", collector.Name(), "active", active, "disabled", disabled)
+	}
+}
+
+// describedMetricNames returns the sorted family names a collector describes.
+func describedMetricNames(collector prometheus.Collector) []string {
+	descs := make(chan *prometheus.Desc)
+	go func() {
+		collector.Describe(descs)
+		close(descs)
+	}()
+
+	seen := map[string]struct{}{}
+	for desc := range descs {
+		if name := descMetricName(desc); name != "" {
+			seen[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// descMetricName returns the family name of desc. prometheus.Desc does not
+// expose it, so it is read from String.
+func descMetricName(desc *prometheus.Desc) string {
+	match := descNamePattern.FindStringSubmatch(desc.String())
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
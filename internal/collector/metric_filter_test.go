@@ -2,12 +2,16 @@ package collector
 
 import (
 	"bytes"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clientModel "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
 )
 
 type metricExpectation struct {
@@ -17,12 +21,10 @@ type metricExpectation struct {
 
 func TestMetricFilter(t *testing.T) {
 	tests := []struct {
-		name            string
-		setEnv          bool
-		envValue        string
-		wantWarn        bool
-		expectations    []metricExpectation
-		warnMustContain []string
+		name         string
+		setEnv       bool
+		envValue     string
+		expectations []metricExpectation
 	}{
 		{
 			name:   "unset env keeps all enabled",
@@ -97,24 +99,13 @@ func TestMetricFilter(t *testing.T) {
 				{metric: metricName("queue", "watermark_bytes_total"), disabled: true},
 			},
 		},
-		{
-			name:     "invalid wildcard is ignored",
-			setEnv:   true,
-			envValue: "sonic_queue_[",
-			wantWarn: true,
-			expectations: []metricExpectation{
-				{metric: metricName("queue", "watermark_bytes_total"), disabled: false},
-			},
-			warnMustContain: []string{"SONIC_DISABLED_METRICS", "sonic_queue_["},
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			configureMetricFilterEnv(t, tt.setEnv, tt.envValue)
 
-			logger, buffer := newMetricFilterLogger()
-			filter := NewMetricFilter(logger)
+			filter := newTestMetricFilter(t, slog.Default())
 
 			for _, expectation := range tt.expectations {
 				if got := filter.Disabled(expectation.metric); got != expectation.disabled {
@@ -125,13 +116,20 @@ func TestMetricFilter(t *testing.T) {
 				}
 			}
 
-			if tt.wantWarn {
-				logOutput := buffer.String()
-				for _, fragment := range tt.warnMustContain {
-					if !strings.Contains(logOutput, fragment) {
-						t.Fatalf("warning log %q does not contain %q", logOutput, fragment)
-					}
-				}
+		})
+	}
+}
+
+func TestMetricFilterInvalidPatterns(t *testing.T) {
+	for _, key := range []string{"SONIC_DISABLED_METRICS", "SONIC_ENABLED_METRICS"} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv("SONIC_DISABLED_METRICS", "")
+			t.Setenv("SONIC_ENABLED_METRICS", "")
+			t.Setenv(key, "sonic_lldp_neighbors, sonic_queue_[")
+
+			_, err := NewMetricFilter(slog.Default())
+			if err == nil || !strings.Contains(err.Error(), key) || !strings.Contains(err.Error(), "sonic_queue_[") {
+				t.Errorf("NewMetricFilter() error = %v, want invalid pattern in %s", err, key)
 			}
 		})
 	}
@@ -160,12 +158,272 @@ func configureMetricFilterEnv(t *testing.T, setEnv bool, value string) {
 	})
 }
 
-func newMetricFilterLogger() (*slog.Logger, *bytes.Buffer) {
+func metricName(subsystem, metric string) string {
+	return prometheus.BuildFQName("sonic", subsystem, metric)
+}
+
+func TestMetricFilterAllowlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      string
+		disabled     string
+		expectations []metricExpectation
+	}{
+		{
+			name:    "allowlist keeps only matching families",
+			enabled: "sonic_interface_*, sonic_lldp_neighbors",
+			expectations: []metricExpectation{
+				{metric: metricName("interface", "operational_status"), disabled: false},
+				{metric: "sonic_lldp_neighbors", disabled: false},
+				{metric: metricName("queue", "watermark_bytes_total"), disabled: true},
+				{metric: metricName("lldp", "neighbor_info"), disabled: true},
+			},
+		},
+		{
+			name:    "allowlist keeps health families",
+			enabled: "sonic_interface_*",
+			expectations: []metricExpectation{
+				{metric: metricName("queue", "collector_success"), disabled: false},
+				{metric: metricName("queue", "scrape_duration_seconds"), disabled: false},
+				{metric: metricName("queue", "cache_age_seconds"), disabled: true},
+			},
+		},
+		{
+			name:     "denylist wins over allowlist",
+			enabled:  "sonic_interface_*",
+			disabled: "sonic_interface_mtu_bytes, sonic_queue_collector_success",
+			expectations: []metricExpectation{
+				{metric: metricName("interface", "operational_status"), disabled: false},
+				{metric: metricName("interface", "mtu_bytes"), disabled: true},
+				{metric: metricName("queue", "collector_success"), disabled: true},
+				{metric: metricName("crm", "collector_success"), disabled: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SONIC_ENABLED_METRICS", tt.enabled)
+			t.Setenv("SONIC_DISABLED_METRICS", tt.disabled)
+
+			filter := newTestMetricFilter(t, slog.Default())
+
+			for _, expectation := range tt.expectations {
+				if got := filter.Disabled(expectation.metric); got != expectation.disabled {
+					t.Errorf("Disabled(%q) = %v, want %v", expectation.metric, got, expectation.disabled)
+				}
+			}
+		})
+	}
+}
+
+func TestLogActiveMetrics(t *testing.T) {
+	t.Setenv("SONIC_ENABLED_METRICS", "sonic_exporter_redis_pool_*")
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+
 	var buffer bytes.Buffer
-	handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelWarn})
-	return slog.New(handler), &buffer
+	logger := slog.New(slog.NewTextHandler(&buffer, nil))
+	filter := newTestMetricFilter(t, logger)
+
+	logActiveMetrics(logger, filter, []SonicCollector{NewRedisPoolCollector(logger, filter, testRedisClient)})
+
+	logOutput := buffer.String()
+	for _, fragment := range []string{"collector=redis_pool", "active=", "sonic_exporter_redis_pool_connections"} {
+		if !strings.Contains(logOutput, fragment) {
+			t.Errorf("log %q does not contain %q", logOutput, fragment)
+		}
+	}
 }
 
-func metricName(subsystem, metric string) string {
-	return prometheus.BuildFQName("sonic", subsystem, metric)
+func TestMetricFilterLabelRules(t *testing.T) {
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `drop sonic_queue_*{device=~"Ethernet(1[0-9]{2})"}; keep sonic_queue_*{queue=~"[0-7]"} ; drop sonic_interface_mtu_bytes{device="eth0", namespace!="asic0"}`)
+
+	filter := newTestMetricFilter(t, slog.Default())
+
+	tests := []struct {
+		metric string
+		labels []string
+		want   bool
+	}{
+		{metric: "sonic_queue_packets_total", labels: []string{"device", "Ethernet0", "queue", "3"}, want: true},
+		{metric: "sonic_queue_packets_total", labels: []string{"device", "Ethernet120", "queue", "3"}, want: false},
+		{metric: "sonic_queue_bytes_total", labels: []string{"device", "Ethernet0", "queue", "12"}, want: false},
+		{metric: "sonic_queue_bytes_total", labels: []string{"device", "Ethernet1200", "queue", "1"}, want: true},
+		{metric: "sonic_interface_mtu_bytes", labels: []string{"device", "eth0", "namespace", ""}, want: false},
+		{metric: "sonic_interface_mtu_bytes", labels: []string{"device", "eth0", "namespace", "asic0"}, want: true},
+		{metric: "sonic_interface_speed_bytes", labels: []string{"device", "eth0", "namespace", ""}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := filter.SeriesEnabled(tt.metric, tt.labels...); got != tt.want {
+			t.Errorf("SeriesEnabled(%q, %q) = %v, want %v", tt.metric, tt.labels, got, tt.want)
+		}
+	}
+
+	names, counts := filter.filtered.snapshot()
+	expectedCounts := map[string]float64{"sonic_queue_packets_total": 1, "sonic_queue_bytes_total": 1, "sonic_interface_mtu_bytes": 1}
+	if len(names) != len(expectedCounts) {
+		t.Fatalf("filtered families = %v, want %v", names, expectedCounts)
+	}
+	for name, count := range expectedCounts {
+		if counts[name] != count {
+			t.Errorf("filtered %s = %v, want %v", name, counts[name], count)
+		}
+	}
+}
+
+func TestMetricFilterInvalidLabelRules(t *testing.T) {
+	for _, rule := range []string{
+		`sonic_queue_*{device="Ethernet0"}`,
+		`ignore sonic_queue_*{device="Ethernet0"}`,
+		`drop sonic_queue_*`,
+		`drop sonic_queue_*{}`,
+		`drop sonic_queue_*{device=Ethernet0}`,
+		`drop sonic_queue_*{device=~"Ethernet("}`,
+		`drop sonic_queue_*{device="Ethernet0" queue="1"}`,
+		`drop sonic_queue_[{device="Ethernet0"}`,
+	} {
+		t.Run(rule, func(t *testing.T) {
+			t.Setenv("SONIC_METRIC_LABEL_RULES", rule)
+
+			_, err := NewMetricFilter(slog.Default())
+			if err == nil || !strings.Contains(err.Error(), "SONIC_METRIC_LABEL_RULES") {
+				t.Errorf("NewMetricFilter() error = %v, want invalid rule %q rejected", err, rule)
+			}
+		})
+	}
+}
+
+func TestFilteredCollector(t *testing.T) {
+	t.Setenv("SONIC_DISABLED_METRICS", "node_load*")
+	t.Setenv("SONIC_METRIC_LABEL_RULES", `drop node_network_*{device=~"veth.*"}`)
+
+	load := prometheus.NewGauge(prometheus.GaugeOpts{Name: "node_load1", Help: "1m load average."})
+	network := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "node_network_receive_bytes_total", Help: "Network device statistic receive_bytes."}, []string{"device"})
+	load.Set(1.5)
+	network.WithLabelValues("eth0").Add(10)
+	network.WithLabelValues("veth1234").Add(20)
+
+	filter := newTestMetricFilter(t, slog.Default())
+	filtered := NewFilteredCollector(upstreamCollector{load, network}, func() MetricFilter { return filter })
+
+	expected := `
+		# HELP node_network_receive_bytes_total Network device statistic receive_bytes.
+		# TYPE node_network_receive_bytes_total counter
+		node_network_receive_bytes_total{device="eth0"} 10
+	`
+	if err := testutil.CollectAndCompare(filtered, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	if _, counts := filter.filtered.snapshot(); counts["node_network_receive_bytes_total"] != 1 {
+		t.Errorf("filtered node_network_receive_bytes_total = %v, want 1", counts["node_network_receive_bytes_total"])
+	}
+}
+
+// upstreamCollector stands in for a node_exporter style collector.
+type upstreamCollector []prometheus.Collector
+
+func (collectors upstreamCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range collectors {
+		collector.Describe(ch)
+	}
+}
+
+func (collectors upstreamCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range collectors {
+		collector.Collect(ch)
+	}
+}
+
+// gatherWithLabelRules gathers the default collectors with rules as
+// SONIC_METRIC_LABEL_RULES and returns the series per family.
+func gatherWithLabelRules(t *testing.T, rules string) map[string][]*clientModel.Metric {
+	t.Helper()
+
+	t.Setenv("SONIC_METRIC_LABEL_RULES", rules)
+	logger := promslog.New(&promslog.Config{})
+	collectors := NewCollectors(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, CollectorFlags{})
+	defer func() {
+		for _, collector := range collectors {
+			collector.Stop()
+		}
+	}()
+
+	registry := prometheus.NewRegistry()
+	for _, collector := range collectors {
+		registry.MustRegister(collector)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	series := map[string][]*clientModel.Metric{}
+	for _, family := range families {
+		series[family.GetName()] = family.GetMetric()
+	}
+
+	return series
+}
+
+func labelValue(metric *clientModel.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+
+	return ""
+}
+
+// TestLabelRulesSeeEveryLabel checks that every label of a series filtered by
+// label rules is passed to the rules, by dropping on each label in turn.
+func TestLabelRulesSeeEveryLabel(t *testing.T) {
+	for _, key := range []string{"PFCWD_ENABLED", "BUFFER_POOL_ENABLED", "RIF_ENABLED", "FDB_ENABLED", "INTERFACE_PFC_ENABLED"} {
+		t.Setenv(key, "true")
+	}
+	t.Setenv("SONIC_DISABLED_METRICS", "")
+	t.Setenv("SONIC_ENABLED_METRICS", "")
+
+	all := gatherWithLabelRules(t, "")
+	// A matcher on a label no series has drops every series of the families
+	// label rules apply to
+	unruled := gatherWithLabelRules(t, `drop sonic_*{no_such_label=""}`)
+
+	ruled := map[string]bool{}
+	labels := map[string]bool{}
+	for name, metrics := range all {
+		if len(unruled[name]) > 0 {
+			continue
+		}
+
+		ruled[name] = true
+		for _, metric := range metrics {
+			for _, label := range metric.GetLabel() {
+				if label.GetValue() != "" {
+					labels[label.GetName()] = true
+				}
+			}
+		}
+	}
+
+	for _, label := range []string{"device", "type", "watermark", "direction", "method", "size", "rif_type"} {
+		if !labels[label] {
+			t.Fatalf("no ruled series with label %s, got labels %v", label, labels)
+		}
+	}
+
+	for label := range labels {
+		filtered := gatherWithLabelRules(t, fmt.Sprintf(`drop sonic_*{%s=~".+"}`, label))
+		for name := range ruled {
+			for _, metric := range filtered[name] {
+				if value := labelValue(metric, label); value != "" {
+					t.Errorf("drop rule on %s kept %s{%s=%q}", label, name, label, value)
+				}
+			}
+		}
+	}
 }
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+// namespaceSnapshot is the latest refresh result of a background refresh
+// collector for a single redis namespace.
+type namespaceSnapshot struct {
+	metrics        []prometheus.Metric
+	skippedEntries float64
+	truncated      float64
+	success        float64
+	refreshTime    time.Time
+}
+
+type namespaceScrapeFunc func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error)
+
+// refreshNamespaces scrapes every namespace with its own timeout. A namespace
+// that fails keeps its previous metrics and reports success 0, so one broken
+// ASIC does not blank the others.
+func refreshNamespaces(logger *slog.Logger, name string, redisClient redis.Client, timeout time.Duration, previous map[string]namespaceSnapshot, scrape namespaceScrapeFunc) map[string]namespaceSnapshot {
+	namespaces := redisClient.Namespaces()
+	snapshots := make(map[string]namespaceSnapshot, len(namespaces))
+
+	for _, namespace := range namespaces {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		metrics, skippedEntries, truncated, err := scrape(ctx, redisClient.Namespace(namespace), namespace)
+		cancel()
+
+		if err != nil {
+			snapshot := previous[namespace]
+			snapshot.success = 0
+			snapshots[namespace] = snapshot
+			logger.Error("Error refreshing "+name+" metrics", "namespace", namespace, "error", err)
+			continue
+		}
+
+		snapshots[namespace] = namespaceSnapshot{
+			metrics:        metrics,
+			skippedEntries: float64(skippedEntries),
+			truncated:      truncated,
+			success:        1,
+			refreshTime:    time.Now(),
+		}
+	}
+
+	return snapshots
+}
+
+func sortedNamespaces(snapshots map[string]namespaceSnapshot) []string {
+	namespaces := make([]string, 0, len(snapshots))
+	for namespace := range snapshots {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// summarizeNamespaces sums skip and truncation counters over all namespaces
+// and reports the age of the oldest successful refresh.
+func summarizeNamespaces(snapshots map[string]namespaceSnapshot) (skippedEntries, truncated, cacheAge float64) {
+	for _, snapshot := range snapshots {
+		skippedEntries += snapshot.skippedEntries
+		truncated = max(truncated, snapshot.truncated)
+
+		if !snapshot.refreshTime.IsZero() {
+			cacheAge = max(cacheAge, time.Since(snapshot.refreshTime).Seconds())
+		}
+	}
+
+	return skippedEntries, truncated, cacheAge
+}
+
+// namespaceHealth is successful only if every namespace refreshed
+// successfully, the last refresh is the one of the oldest namespace.
+func namespaceHealth(snapshots map[string]namespaceSnapshot, refreshInterval time.Duration) CollectorHealth {
+	health := CollectorHealth{Success: len(snapshots) > 0, RefreshInterval: refreshInterval}
+	first := true
+	for _, snapshot := range snapshots {
+		if snapshot.success != 1 {
+			health.Success = false
+		}
+		if first || snapshot.refreshTime.Before(health.LastRefresh) {
+			health.LastRefresh = snapshot.refreshTime
+			first = false
+		}
+	}
+
+	return health
+}
@@ -0,0 +1,391 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const (
+	pfcwdStormDetectedMetricName    = "sonic_pfcwd_storm_detected_total"
+	pfcwdStormRestoredMetricName    = "sonic_pfcwd_storm_restored_total"
+	pfcwdStormActiveMetricName      = "sonic_pfcwd_storm_active"
+	pfcwdPacketsMetricName          = "sonic_pfcwd_packets_total"
+	pfcwdDroppedPacketsMetricName   = "sonic_pfcwd_dropped_packets_total"
+	pfcwdDetectionTimeMetricName    = "sonic_pfcwd_detection_time_seconds"
+	pfcwdRestorationTimeMetricName  = "sonic_pfcwd_restoration_time_seconds"
+	pfcwdScrapeDurationMetricName   = "sonic_pfcwd_scrape_duration_seconds"
+	pfcwdCollectorSuccessMetricName = "sonic_pfcwd_collector_success"
+	pfcwdCacheAgeMetricName         = "sonic_pfcwd_cache_age_seconds"
+	pfcwdEntriesSkippedMetricName   = "sonic_pfcwd_entries_skipped"
+)
+
+type pfcwdCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+	redisScanCount  int64
+}
+
+type pfcwdCollector struct {
+	stormDetected          *prometheus.Desc
+	stormRestored          *prometheus.Desc
+	stormActive            *prometheus.Desc
+	packets                *prometheus.Desc
+	droppedPackets         *prometheus.Desc
+	detectionTime          *prometheus.Desc
+	restorationTime        *prometheus.Desc
+	scrapeDuration         *prometheus.Desc
+	scrapeCollectorSuccess *prometheus.Desc
+	cacheAge               *prometheus.Desc
+	entriesSkipped         *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       pfcwdCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+}
+
+func NewPfcwdCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *pfcwdCollector {
+	return newPfcwdCollector(logger, metricFilter, redisClient, loadPfcwdCollectorConfig(logger))
+}
+
+func newPfcwdCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config pfcwdCollectorConfig) *pfcwdCollector {
+	const (
+		namespace = "sonic"
+		subsystem = "pfcwd"
+	)
+
+	collector := &pfcwdCollector{
+		stormDetected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "storm_detected_total"),
+			"Number of PFC storms detected on a queue", []string{"device", "queue", "namespace"}, nil),
+		stormRestored: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "storm_restored_total"),
+			"Number of PFC storms restored on a queue", []string{"device", "queue", "namespace"}, nil),
+		stormActive: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "storm_active"),
+			"Whether a PFC storm is active on a queue (1=stormed, 0=operational)", []string{"device", "queue", "namespace"}, nil),
+		packets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "packets_total"),
+			"Number of packets seen by PFC watchdog on a queue by direction: rx, tx", []string{"device", "queue", "direction", "namespace"}, nil),
+		droppedPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dropped_packets_total"),
+			"Number of packets dropped by PFC watchdog on a queue by direction: rx, tx", []string{"device", "queue", "direction", "namespace"}, nil),
+		detectionTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "detection_time_seconds"),
+			"Configured PFC storm detection time of a port", []string{"device", "namespace"}, nil),
+		restorationTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "restoration_time_seconds"),
+			"Configured PFC storm restoration time of a port", []string{"device", "namespace"}, nil),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
+			"Time it took for exporter to refresh PFC watchdog metrics", nil, nil),
+		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
+			"Whether PFC watchdog collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest PFC watchdog cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of PFC watchdog entries skipped during latest refresh", nil, nil),
+		logger:       logger,
+		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("PFC watchdog collector is disabled")
+		return collector
+	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *pfcwdCollector) Name() string {
+	return "pfcwd"
+}
+
+func (collector *pfcwdCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *pfcwdCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *pfcwdCollector) Stop() {
+	close(collector.stop)
+}
+
+func (collector *pfcwdCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.stormDetected
+	ch <- collector.stormRestored
+	ch <- collector.stormActive
+	ch <- collector.packets
+	ch <- collector.droppedPackets
+	ch <- collector.detectionTime
+	ch <- collector.restorationTime
+	ch <- collector.scrapeDuration
+	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
+}
+
+func (collector *pfcwdCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
+
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
+
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled(pfcwdCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
+	}
+
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(pfcwdScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
+	}
+	if collector.metricFilter.Enabled(pfcwdCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
+	}
+	if collector.metricFilter.Enabled(pfcwdEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
+	}
+}
+
+func (collector *pfcwdCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
+	}
+}
+
+func (collector *pfcwdCollector) refreshMetrics() {
+	start := time.Now()
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "PFC watchdog", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
+
+func (collector *pfcwdCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
+	metrics := []prometheus.Metric{}
+
+	skippedQueues, err := collector.collectQueueStats(ctx, redisClient, &metrics, namespace)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PFC watchdog queue stats collection failed: %w", err)
+	}
+
+	skippedPorts, err := collector.collectPortConfig(ctx, redisClient, &metrics, namespace)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PFC watchdog config collection failed: %w", err)
+	}
+
+	return metrics, skippedQueues + skippedPorts, nil
+}
+
+// collectQueueStats collects the PFC watchdog state and counters SONiC keeps
+// in the COUNTERS_DB entry of every watched queue. Queues without
+// PFC_WD_STATUS are not watched and are ignored.
+func (collector *pfcwdCollector) collectQueueStats(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, namespace string) (int, error) {
+	queues, _, err := readPortIndexMap(ctx, redisClient, "COUNTERS_QUEUE_NAME_MAP", "COUNTERS_QUEUE_PORT_MAP")
+	if err != nil {
+		return 0, err
+	}
+
+	counterKeys := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		counterKeys = append(counterKeys, "COUNTERS:"+queue.oid)
+	}
+
+	queueStats, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", counterKeys)
+	if err != nil {
+		return 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	skippedEntries := 0
+	for i, queue := range queues {
+		stats := queueStats[i]
+
+		status, ok := stats["PFC_WD_STATUS"]
+		if !ok {
+			continue
+		}
+
+		values, err := parsePfcwdQueueStats(stats)
+		if err != nil {
+			skippedEntries++
+			continue
+		}
+
+		stormActive := 0.0
+		if status == "stormed" {
+			stormActive = 1
+		}
+
+		if collector.metricFilter.SeriesEnabled(pfcwdStormActiveMetricName, "device", queue.device, "queue", queue.index, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.stormActive, prometheus.GaugeValue, stormActive, queue.device, queue.index, namespace,
+			))
+		}
+
+		if collector.metricFilter.SeriesEnabled(pfcwdStormDetectedMetricName, "device", queue.device, "queue", queue.index, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.stormDetected, prometheus.CounterValue, values["DEADLOCK_DETECTED"], queue.device, queue.index, namespace,
+			))
+		}
+
+		if collector.metricFilter.SeriesEnabled(pfcwdStormRestoredMetricName, "device", queue.device, "queue", queue.index, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(
+				collector.stormRestored, prometheus.CounterValue, values["DEADLOCK_RESTORED"], queue.device, queue.index, namespace,
+			))
+		}
+
+		for _, direction := range []string{"rx", "tx"} {
+			prefix := strings.ToUpper(direction)
+
+			if collector.metricFilter.SeriesEnabled(pfcwdPacketsMetricName, "device", queue.device, "queue", queue.index, "direction", direction, "namespace", namespace) {
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					collector.packets, prometheus.CounterValue, values[prefix+"_PACKETS"], queue.device, queue.index, direction, namespace,
+				))
+			}
+
+			if collector.metricFilter.SeriesEnabled(pfcwdDroppedPacketsMetricName, "device", queue.device, "queue", queue.index, "direction", direction, "namespace", namespace) {
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					collector.droppedPackets, prometheus.CounterValue, values[prefix+"_DROPPED_PACKETS"], queue.device, queue.index, direction, namespace,
+				))
+			}
+		}
+	}
+
+	return skippedEntries, nil
+}
+
+// parsePfcwdQueueStats parses the PFC_WD_QUEUE_STATS_* counters of a queue,
+// keyed by the name without prefix. Missing counters are 0.
+func parsePfcwdQueueStats(stats map[string]string) (map[string]float64, error) {
+	names := []string{"DEADLOCK_DETECTED", "DEADLOCK_RESTORED", "RX_PACKETS", "RX_DROPPED_PACKETS", "TX_PACKETS", "TX_DROPPED_PACKETS"}
+
+	values := make(map[string]float64, len(names))
+	for _, name := range names {
+		value, err := parseFloat(stats["PFC_WD_QUEUE_STATS_"+name])
+		if err != nil {
+			return nil, fmt.Errorf("value parse failed: %w", err)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// collectPortConfig collects the detection and restoration times configured
+// in CONFIG_DB PFC_WD|<port>. They are in milliseconds. A time missing from
+// the entry has no series, one that fails to parse is logged and counted as
+// skipped.
+func (collector *pfcwdCollector) collectPortConfig(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, namespace string) (int, error) {
+	keys, err := redisClient.ScanKeysFromDb(ctx, "CONFIG_DB", "PFC_WD|*", collector.config.redisScanCount)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan PFC_WD keys: %w", err)
+	}
+
+	portKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		// PFC_WD|GLOBAL holds the poll interval, not a port
+		if key == "PFC_WD|GLOBAL" {
+			continue
+		}
+		portKeys = append(portKeys, key)
+	}
+	sort.Strings(portKeys)
+
+	configs, err := redisClient.HgetAllManyFromDb(ctx, "CONFIG_DB", portKeys)
+	if err != nil {
+		return 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	times := []struct {
+		field      string
+		metricName string
+		desc       *prometheus.Desc
+	}{
+		{field: "detection_time", metricName: pfcwdDetectionTimeMetricName, desc: collector.detectionTime},
+		{field: "restoration_time", metricName: pfcwdRestorationTimeMetricName, desc: collector.restorationTime},
+	}
+
+	skippedEntries := 0
+	for i, key := range portKeys {
+		port := strings.TrimPrefix(key, "PFC_WD|")
+
+		for _, configTime := range times {
+			value, ok := configs[i][configTime.field]
+			if !ok {
+				continue
+			}
+
+			milliseconds, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				collector.logger.Warn("Failed to parse PFC watchdog time", "key", key, "field", configTime.field, "value", value, "namespace", namespace, "error", err)
+				skippedEntries++
+				continue
+			}
+
+			if collector.metricFilter.SeriesEnabled(configTime.metricName, "device", port, "namespace", namespace) {
+				*metrics = append(*metrics, prometheus.MustNewConstMetric(
+					configTime.desc, prometheus.GaugeValue, milliseconds/1000, port, namespace,
+				))
+			}
+		}
+	}
+
+	return skippedEntries, nil
+}
+
+func loadPfcwdCollectorConfig(logger *slog.Logger) pfcwdCollectorConfig {
+	return pfcwdCollectorConfig{
+		enabled:         parseBoolEnv(logger, "PFCWD_ENABLED", false),
+		refreshInterval: parseDurationEnv(logger, "PFCWD_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "PFCWD_TIMEOUT", 5*time.Second),
+		redisScanCount:  256,
+	}
+}
@@ -44,7 +44,9 @@ type platformHealthCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       platformHealthCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
 	cachedMetrics      []prometheus.Metric
@@ -55,7 +57,11 @@ type platformHealthCollector struct {
 	lastRefreshTime    time.Time
 }
 
-func NewPlatformHealthCollector(logger *slog.Logger, metricFilter MetricFilter) *platformHealthCollector {
+func NewPlatformHealthCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *platformHealthCollector {
+	return newPlatformHealthCollector(logger, metricFilter, redisClient, loadPlatformHealthCollectorConfig(logger))
+}
+
+func newPlatformHealthCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config platformHealthCollectorConfig) *platformHealthCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "platform"
@@ -100,14 +106,9 @@ func NewPlatformHealthCollector(logger *slog.Logger, metricFilter MetricFilter)
 			"Age of latest platform health cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config: platformHealthCollectorConfig{
-			enabled:           parseBoolEnv(logger, "PLATFORM_HEALTH_ENABLED", false),
-			refreshInterval:   parseDurationEnv(logger, "PLATFORM_HEALTH_REFRESH_INTERVAL", 60*time.Second),
-			timeout:           parseDurationEnv(logger, "PLATFORM_HEALTH_TIMEOUT", 2*time.Second),
-			maxProcesses:      parseIntEnv(logger, "PLATFORM_HEALTH_MAX_PROCESSES", 512),
-			maxStorageDevices: parseIntEnv(logger, "PLATFORM_HEALTH_MAX_STORAGE_DEVICES", 128),
-			redisScanCount:    256,
-		},
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -144,6 +145,21 @@ func (collector *platformHealthCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.cacheAge
 }
 
+func (collector *platformHealthCollector) Name() string {
+	return "platform_health"
+}
+
+func (collector *platformHealthCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *platformHealthCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *platformHealthCollector) Collect(ch chan<- prometheus.Metric) {
 	if !collector.config.enabled {
 		return
@@ -186,8 +202,13 @@ func (collector *platformHealthCollector) Collect(ch chan<- prometheus.Metric) {
 func (collector *platformHealthCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -214,11 +235,7 @@ func (collector *platformHealthCollector) refreshMetrics() {
 }
 
 func (collector *platformHealthCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	metrics := []prometheus.Metric{}
 	skippedEntries := 0
@@ -347,3 +364,14 @@ func (collector *platformHealthCollector) scrapeMetrics(ctx context.Context) ([]
 
 	return metrics, skippedEntries, truncated, nil
 }
+
+func loadPlatformHealthCollectorConfig(logger *slog.Logger) platformHealthCollectorConfig {
+	return platformHealthCollectorConfig{
+		enabled:           parseBoolEnv(logger, "PLATFORM_HEALTH_ENABLED", false),
+		refreshInterval:   parseDurationEnv(logger, "PLATFORM_HEALTH_REFRESH_INTERVAL", 60*time.Second),
+		timeout:           parseDurationEnv(logger, "PLATFORM_HEALTH_TIMEOUT", 2*time.Second),
+		maxProcesses:      parseIntEnv(logger, "PLATFORM_HEALTH_MAX_PROCESSES", 512),
+		maxStorageDevices: parseIntEnv(logger, "PLATFORM_HEALTH_MAX_STORAGE_DEVICES", 128),
+		redisScanCount:    256,
+	}
+}
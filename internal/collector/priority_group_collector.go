@@ -0,0 +1,302 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const (
+	priorityGroupPacketsMetricName          = "sonic_priority_group_packets_total"
+	priorityGroupBytesMetricName            = "sonic_priority_group_bytes_total"
+	priorityGroupDroppedPacketsMetricName   = "sonic_priority_group_dropped_packets_total"
+	priorityGroupWatermarkBytesMetricName   = "sonic_priority_group_watermark_bytes"
+	priorityGroupScrapeDurationMetricName   = "sonic_priority_group_scrape_duration_seconds"
+	priorityGroupCollectorSuccessMetricName = "sonic_priority_group_collector_success"
+	priorityGroupCacheAgeMetricName         = "sonic_priority_group_cache_age_seconds"
+	priorityGroupEntriesSkippedMetricName   = "sonic_priority_group_entries_skipped"
+)
+
+type priorityGroupCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+}
+
+type priorityGroupCollector struct {
+	packets                *prometheus.Desc
+	bytes                  *prometheus.Desc
+	droppedPackets         *prometheus.Desc
+	watermarkBytes         *prometheus.Desc
+	scrapeDuration         *prometheus.Desc
+	scrapeCollectorSuccess *prometheus.Desc
+	cacheAge               *prometheus.Desc
+	entriesSkipped         *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       priorityGroupCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+}
+
+func NewPriorityGroupCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *priorityGroupCollector {
+	return newPriorityGroupCollector(logger, metricFilter, redisClient, loadPriorityGroupCollectorConfig(logger))
+}
+
+func newPriorityGroupCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config priorityGroupCollectorConfig) *priorityGroupCollector {
+	const (
+		namespace = "sonic"
+		subsystem = "priority_group"
+	)
+
+	collector := &priorityGroupCollector{
+		packets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "packets_total"),
+			"Number of packets received in an ingress priority group", []string{"device", "pg", "namespace"}, nil),
+		bytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bytes_total"),
+			"Number of bytes received in an ingress priority group", []string{"device", "pg", "namespace"}, nil),
+		droppedPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dropped_packets_total"),
+			"Number of packets dropped in an ingress priority group", []string{"device", "pg", "namespace"}, nil),
+		watermarkBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "watermark_bytes"),
+			"Buffer watermark of an ingress priority group", []string{"device", "pg", "type", "watermark", "namespace"}, nil),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
+			"Time it took for exporter to refresh priority group metrics", nil, nil),
+		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
+			"Whether priority group collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest priority group cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of priority group name map entries skipped during latest refresh", nil, nil),
+		logger:       logger,
+		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("Priority group collector is disabled")
+		return collector
+	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *priorityGroupCollector) Name() string {
+	return "priority_group"
+}
+
+func (collector *priorityGroupCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *priorityGroupCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *priorityGroupCollector) Stop() {
+	close(collector.stop)
+}
+
+func (collector *priorityGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.packets
+	ch <- collector.bytes
+	ch <- collector.droppedPackets
+	ch <- collector.watermarkBytes
+	ch <- collector.scrapeDuration
+	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
+}
+
+func (collector *priorityGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
+
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
+
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled(priorityGroupCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
+	}
+
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(priorityGroupScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
+	}
+	if collector.metricFilter.Enabled(priorityGroupCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
+	}
+	if collector.metricFilter.Enabled(priorityGroupEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
+	}
+}
+
+func (collector *priorityGroupCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
+	}
+}
+
+func (collector *priorityGroupCollector) refreshMetrics() {
+	start := time.Now()
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "priority group", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
+
+// scrapeMetrics collects the ingress priority groups of COUNTERS_PG_NAME_MAP:
+// counters from COUNTERS:<oid> and the xoff room (headroom) and shared
+// watermarks of every watermark type, like "show priority-group watermark".
+// It returns the number of malformed COUNTERS_PG_NAME_MAP keys.
+func (collector *priorityGroupCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
+	metrics := []prometheus.Metric{}
+
+	pgCounters := []struct {
+		metricName string
+		field      string
+		desc       *prometheus.Desc
+	}{
+		{priorityGroupPacketsMetricName, "SAI_INGRESS_PRIORITY_GROUP_STAT_PACKETS", collector.packets},
+		{priorityGroupBytesMetricName, "SAI_INGRESS_PRIORITY_GROUP_STAT_BYTES", collector.bytes},
+		{priorityGroupDroppedPacketsMetricName, "SAI_INGRESS_PRIORITY_GROUP_STAT_DROPPED_PACKETS", collector.droppedPackets},
+	}
+
+	countersEnabled := false
+	for _, counter := range pgCounters {
+		countersEnabled = countersEnabled || collector.metricFilter.Enabled(counter.metricName)
+	}
+	watermarksEnabled := collector.metricFilter.Enabled(priorityGroupWatermarkBytesMetricName)
+	if !countersEnabled && !watermarksEnabled {
+		return metrics, 0, nil
+	}
+
+	pgs, skippedEntries, err := readPortIndexMap(ctx, redisClient, "COUNTERS_PG_NAME_MAP", "COUNTERS_PG_PORT_MAP")
+	if err != nil {
+		return nil, 0, err
+	}
+	if skippedEntries > 0 {
+		collector.logger.Debug("Skipping malformed priority group name map keys", "count", skippedEntries, "namespace", namespace)
+	}
+
+	if countersEnabled {
+		counterKeys := make([]string, 0, len(pgs))
+		for _, pg := range pgs {
+			counterKeys = append(counterKeys, "COUNTERS:"+pg.oid)
+		}
+
+		counters, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", counterKeys)
+		if err != nil {
+			return nil, 0, fmt.Errorf("redis read failed: %w", err)
+		}
+
+		for i, pg := range pgs {
+			for _, counter := range pgCounters {
+				value, err := parseFloat(counters[i][counter.field])
+				if err != nil {
+					return nil, 0, fmt.Errorf("value parse failed: %w", err)
+				}
+
+				if collector.metricFilter.SeriesEnabled(counter.metricName, "device", pg.device, "pg", pg.index, "namespace", namespace) {
+					metrics = append(metrics, prometheus.MustNewConstMetric(
+						counter.desc, prometheus.CounterValue, value, pg.device, pg.index, namespace,
+					))
+				}
+			}
+		}
+	}
+
+	if !watermarksEnabled {
+		return metrics, skippedEntries, nil
+	}
+
+	for _, watermarkType := range []string{"USER", "PERSISTENT", "PERIODIC"} {
+		watermarkKeys := make([]string, 0, len(pgs))
+		for _, pg := range pgs {
+			watermarkKeys = append(watermarkKeys, fmt.Sprintf("%s_WATERMARKS:%s", watermarkType, pg.oid))
+		}
+
+		watermarks, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", watermarkKeys)
+		if err != nil {
+			return nil, 0, fmt.Errorf("redis read failed: %w", err)
+		}
+
+		for i, pg := range pgs {
+			for _, watermarkKey := range []string{"SAI_INGRESS_PRIORITY_GROUP_STAT_XOFF_ROOM_WATERMARK_BYTES", "SAI_INGRESS_PRIORITY_GROUP_STAT_SHARED_WATERMARK_BYTES"} {
+				value, ok := watermarks[i][watermarkKey]
+				if !ok {
+					continue
+				}
+
+				watermarkValue, err := parseFloat(value)
+				if err != nil {
+					return nil, 0, fmt.Errorf("value parse failed: %w", err)
+				}
+
+				typeLabel, watermark := strings.ToLower(watermarkType), watermarkLabel(watermarkKey)
+				if collector.metricFilter.SeriesEnabled(priorityGroupWatermarkBytesMetricName, "device", pg.device, "pg", pg.index, "type", typeLabel, "watermark", watermark, "namespace", namespace) {
+					metrics = append(metrics, prometheus.MustNewConstMetric(
+						collector.watermarkBytes, prometheus.GaugeValue, watermarkValue,
+						pg.device, pg.index, typeLabel, watermark, namespace,
+					))
+				}
+			}
+		}
+	}
+
+	return metrics, skippedEntries, nil
+}
+
+func loadPriorityGroupCollectorConfig(logger *slog.Logger) priorityGroupCollectorConfig {
+	return priorityGroupCollectorConfig{
+		enabled:         parseBoolEnv(logger, "PRIORITY_GROUP_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "PRIORITY_GROUP_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "PRIORITY_GROUP_TIMEOUT", 5*time.Second),
+	}
+}
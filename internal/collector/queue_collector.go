@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,139 +18,318 @@ const (
 	queueBytesMetricName                = "sonic_queue_bytes_total"
 	queueDroppedPacketsMetricName       = "sonic_queue_dropped_packets_total"
 	queueDroppedBytesMetricName         = "sonic_queue_dropped_bytes_total"
+	queueSharedWatermarkMetricName      = "sonic_queue_shared_watermark_bytes"
+	queueWatermarkMetricName            = "sonic_queue_watermark_bytes"
+	queuePeriodicIntervalMetricName     = "sonic_queue_watermark_periodic_interval_seconds"
 	queueSharedWatermarkBytesMetricName = "sonic_queue_shared_watermark_bytes_total"
 	queueWatermarkBytesMetricName       = "sonic_queue_watermark_bytes_total"
 	queueScrapeDurationMetricName       = "sonic_queue_scrape_duration_seconds"
 	queueCollectorSuccessMetricName     = "sonic_queue_collector_success"
+	queueCacheAgeMetricName             = "sonic_queue_cache_age_seconds"
+	queueEntriesSkippedMetricName       = "sonic_queue_entries_skipped"
 )
 
+// queueSharedWatermarkStat is the shared buffer watermark of a queue, in
+// COUNTERS:<oid> and in every <TYPE>_WATERMARKS:<oid>.
+const queueSharedWatermarkStat = "SAI_QUEUE_STAT_SHARED_WATERMARK_BYTES"
+
+// portIndexEntry is a queue or priority group of a port, listed in
+// COUNTERS_QUEUE_NAME_MAP or COUNTERS_PG_NAME_MAP.
+type portIndexEntry struct {
+	device string
+	index  string
+	oid    string
+}
+
+// defaultWatermarkTelemetryInterval is the periodic watermark interval SONiC
+// uses when WATERMARK_TABLE|TELEMETRY_INTERVAL is not configured.
+const defaultWatermarkTelemetryInterval = 120 * time.Second
+
+type queueCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+	// legacyWatermarks also exports the deprecated counter typed
+	// *_watermark_bytes_total families next to the gauges
+	legacyWatermarks bool
+}
+
 type queueCollector struct {
 	queuePackets              *prometheus.Desc
 	queueBytes                *prometheus.Desc
 	queueDroppedPackets       *prometheus.Desc
 	queueDroppedBytes         *prometheus.Desc
+	queueSharedWatermark      *prometheus.Desc
+	queueWatermark            *prometheus.Desc
+	queuePeriodicInterval     *prometheus.Desc
 	queueSharedWatermarkBytes *prometheus.Desc
 	queueWatermarksBytes      *prometheus.Desc
 	scrapeDuration            *prometheus.Desc
 	scrapeCollectorSuccess    *prometheus.Desc
-	cachedMetrics             []prometheus.Metric
-	lastScrapeTime            time.Time
-	logger                    *slog.Logger
-	metricFilter              MetricFilter
-	mu                        sync.Mutex
+	cacheAge                  *prometheus.Desc
+	entriesSkipped            *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       queueCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+}
+
+func NewQueueCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *queueCollector {
+	return newQueueCollector(logger, metricFilter, redisClient, loadQueueCollectorConfig(logger))
 }
 
-func NewQueueCollector(logger *slog.Logger, metricFilter MetricFilter) *queueCollector {
+func newQueueCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config queueCollectorConfig) *queueCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "queue"
 	)
 
-	return &queueCollector{
+	collector := &queueCollector{
 		queuePackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "packets_total"),
-			"Number of packets in a queue", []string{"device", "queue"}, nil),
+			"Number of packets in a queue", []string{"device", "queue", "queue_type", "namespace"}, nil),
 		queueBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bytes_total"),
-			"Number of bytes in a queue", []string{"device", "queue"}, nil),
+			"Number of bytes in a queue", []string{"device", "queue", "queue_type", "namespace"}, nil),
 		queueDroppedPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dropped_packets_total"),
-			"Number of dropped packets in a queue", []string{"device", "queue"}, nil),
+			"Number of dropped packets in a queue", []string{"device", "queue", "queue_type", "namespace"}, nil),
 		queueDroppedBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dropped_bytes_total"),
-			"Number of dropped bytes in a queue", []string{"device", "queue"}, nil),
+			"Number of dropped bytes in a queue", []string{"device", "queue", "queue_type", "namespace"}, nil),
+		queueSharedWatermark: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "shared_watermark_bytes"),
+			"Shared buffer watermark of a queue", []string{"device", "queue", "queue_type", "namespace"}, nil),
+		queueWatermark: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "watermark_bytes"),
+			"Buffer watermark of a queue by watermark type: user, persistent, periodic", []string{"device", "queue", "queue_type", "type", "namespace"}, nil),
+		queuePeriodicInterval: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "watermark_periodic_interval_seconds"),
+			"Interval at which periodic watermarks are read and cleared", []string{"namespace"}, nil),
 		queueSharedWatermarkBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "shared_watermark_bytes_total"),
-			"Number of shared watermark bytes in a queue", []string{"device", "queue"}, nil),
+			"Deprecated: use sonic_queue_shared_watermark_bytes", []string{"device", "queue", "queue_type", "namespace"}, nil),
 		queueWatermarksBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "watermark_bytes_total"),
-			"Network device property: watermarks of queue", []string{"device", "queue", "type", "watermark"}, nil),
+			"Deprecated: use sonic_queue_watermark_bytes", []string{"device", "queue", "queue_type", "type", "watermark", "namespace"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
-			"Time it took for prometheus to scrape sonic queue metrics", nil, nil),
+			"Time it took for exporter to refresh queue metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether queue collector succeeded", nil, nil),
+			"Whether queue collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest queue cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of queue name map entries skipped during latest refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
-}
 
-func (collector *queueCollector) Collect(ch chan<- prometheus.Metric) {
-	const cacheDuration = 15 * time.Second
+	if !collector.config.enabled {
+		collector.logger.Info("Queue collector is disabled")
+		return collector
+	}
 
-	scrapeSuccess := 1.0
+	collector.refreshMetrics()
+	go collector.refreshLoop()
 
-	var ctx = context.Background()
+	return collector
+}
 
-	collector.mu.Lock()
-	defer collector.mu.Unlock()
+func (collector *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
 
-	if time.Since(collector.lastScrapeTime) < cacheDuration {
-		// Return cached metrics without making redis calls
-		collector.logger.Info("Returning queue metrics from cache")
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
 
-		for _, metric := range collector.cachedMetrics {
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
 			ch <- metric
 		}
-		return
+		if collector.metricFilter.Enabled(queueCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	err := collector.scrapeMetrics(ctx)
-	if err != nil {
-		scrapeSuccess = 0
-		collector.logger.Error("Error scraping metrics", "error", err)
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(queueScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled(queueCollectorSuccessMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeCollectorSuccess, prometheus.GaugeValue, scrapeSuccess,
-		))
+	if collector.metricFilter.Enabled(queueCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
-
-	for _, cachedMetric := range collector.cachedMetrics {
-		ch <- cachedMetric
+	if collector.metricFilter.Enabled(queueEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
 	}
 }
 
-func (collector *queueCollector) scrapeMetrics(ctx context.Context) error {
-	collector.logger.Info("Starting queue metric scrape")
-	scrapeTime := time.Now()
+func (collector *queueCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
 
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return fmt.Errorf("redis client initialization failed: %w", err)
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
+}
+
+func (collector *queueCollector) refreshMetrics() {
+	start := time.Now()
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "queue", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
 
-	defer redisClient.Close()
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
 
-	// Reset metrics
-	collector.cachedMetrics = []prometheus.Metric{}
+func (collector *queueCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
+	metrics := []prometheus.Metric{}
 
-	queues, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_QUEUE_NAME_MAP")
+	queues, skippedEntries, err := readPortIndexMap(ctx, redisClient, "COUNTERS_QUEUE_NAME_MAP", "COUNTERS_QUEUE_PORT_MAP")
 	if err != nil {
-		return fmt.Errorf("redis read failed: %w", err)
+		return nil, 0, err
+	}
+	if skippedEntries > 0 {
+		collector.logger.Debug("Skipping malformed queue name map keys", "count", skippedEntries, "namespace", namespace)
 	}
 
-	for queue := range queues {
-		interfaceName := strings.Split(queue, ":")[0]
-		queueNumber := strings.Split(queue, ":")[1]
+	queueTypes, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_QUEUE_TYPE_MAP")
+	if err != nil {
+		return nil, 0, fmt.Errorf("redis read failed: %w", err)
+	}
 
-		counterKey := fmt.Sprintf("COUNTERS:%s", queues[queue])
+	for _, queue := range queues {
+		counterKey := fmt.Sprintf("COUNTERS:%s", queue.oid)
+		queueType := queueTypeLabel(queueTypes[queue.oid])
 
-		err := collector.collectQueueCounters(ctx, redisClient, interfaceName, queueNumber, counterKey)
+		err := collector.collectQueueCounters(ctx, redisClient, &metrics, queue.device, queue.index, queueType, counterKey, namespace)
 		if err != nil {
-			return fmt.Errorf("queue counters collection failed: %w", err)
+			return nil, 0, fmt.Errorf("queue counters collection failed: %w", err)
 		}
+	}
 
-		if collector.metricFilter.Enabled(queueWatermarkBytesMetricName) {
-			err = collector.collectQueueWatermarks(ctx, redisClient, interfaceName, queueNumber, queues[queue])
-			if err != nil {
-				return fmt.Errorf("queue watermarks collection failed: %w", err)
-			}
+	if collector.watermarksEnabled() {
+		err = collector.collectQueueWatermarks(ctx, redisClient, &metrics, queues, queueTypes, namespace)
+		if err != nil {
+			return nil, 0, fmt.Errorf("queue watermarks collection failed: %w", err)
 		}
 	}
 
-	collector.logger.Info("Ending queue metric scrape")
+	if collector.metricFilter.SeriesEnabled(queuePeriodicIntervalMetricName, "namespace", namespace) {
+		err = collector.collectPeriodicInterval(ctx, redisClient, &metrics, namespace)
+		if err != nil {
+			return nil, 0, fmt.Errorf("watermark interval collection failed: %w", err)
+		}
+	}
 
-	collector.lastScrapeTime = time.Now()
-	if collector.metricFilter.Enabled(queueScrapeDurationMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics, prometheus.MustNewConstMetric(
-			collector.scrapeDuration, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(),
-		))
+	return metrics, skippedEntries, nil
+}
+
+// queueTypeLabel maps a COUNTERS_QUEUE_TYPE_MAP value to the queue_type label.
+// Missing and unknown SAI types are "unknown", so the label stays bounded.
+func queueTypeLabel(saiQueueType string) string {
+	switch saiQueueType {
+	case "SAI_QUEUE_TYPE_UNICAST":
+		return "unicast"
+	case "SAI_QUEUE_TYPE_MULTICAST":
+		return "multicast"
+	case "SAI_QUEUE_TYPE_ALL":
+		return "all"
+	default:
+		return "unknown"
 	}
-	return nil
+}
+
+// readPortIndexMap reads a COUNTERS_DB name map like COUNTERS_QUEUE_NAME_MAP,
+// whose keys are "<device>:<index>" and values OIDs. The device is the port
+// of the OID in portMapName, e.g. COUNTERS_QUEUE_PORT_MAP, named through
+// COUNTERS_PORT_NAME_MAP. The device of the key is only used when the OID has
+// no named port there. Entries are sorted by device and index. Keys without a
+// device or an index are skipped and counted.
+func readPortIndexMap(ctx context.Context, redisClient redis.Client, mapName, portMapName string) ([]portIndexEntry, int, error) {
+	nameMap, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", mapName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	portMap, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", portMapName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	portNames := map[string]string{}
+	if len(portMap) > 0 {
+		ports, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_PORT_NAME_MAP")
+		if err != nil {
+			return nil, 0, fmt.Errorf("redis read failed: %w", err)
+		}
+		for name, portOid := range ports {
+			portNames[portOid] = name
+		}
+	}
+
+	entries := make([]portIndexEntry, 0, len(nameMap))
+	skippedEntries := 0
+	for key, oid := range nameMap {
+		device, index, ok := strings.Cut(key, ":")
+		if !ok || device == "" || index == "" {
+			skippedEntries++
+			continue
+		}
+
+		if port, ok := portNames[portMap[oid]]; ok {
+			device = port
+		}
+
+		entries = append(entries, portIndexEntry{device: device, index: index, oid: oid})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].device != entries[j].device {
+			return entries[i].device < entries[j].device
+		}
+		return entries[i].index < entries[j].index
+	})
+
+	return entries, skippedEntries, nil
+}
+
+func (collector *queueCollector) Name() string {
+	return "queue"
+}
+
+func (collector *queueCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *queueCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *queueCollector) Stop() {
+	close(collector.stop)
 }
 
 func (collector *queueCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -157,13 +337,20 @@ func (collector *queueCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.queueBytes
 	ch <- collector.queueDroppedPackets
 	ch <- collector.queueDroppedBytes
-	ch <- collector.queueSharedWatermarkBytes
-	ch <- collector.queueWatermarksBytes
+	ch <- collector.queueSharedWatermark
+	ch <- collector.queueWatermark
+	ch <- collector.queuePeriodicInterval
+	if collector.config.legacyWatermarks {
+		ch <- collector.queueSharedWatermarkBytes
+		ch <- collector.queueWatermarksBytes
+	}
 	ch <- collector.scrapeDuration
 	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
 }
 
-func (collector *queueCollector) collectQueueCounters(ctx context.Context, redisClient redis.Client, interfaceName, queueNumber, counterKey string) error {
+func (collector *queueCollector) collectQueueCounters(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, interfaceName, queueNumber, queueType, counterKey, namespace string) error {
 	var counters map[string]string
 
 	// Retrieve packet counters from redis database
@@ -177,10 +364,10 @@ func (collector *queueCollector) collectQueueCounters(ctx context.Context, redis
 		return fmt.Errorf("value parse failed: %w", err)
 	}
 
-	if collector.metricFilter.Enabled(queuePacketsMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics,
+	if collector.metricFilter.SeriesEnabled(queuePacketsMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
 			prometheus.MustNewConstMetric(
-				collector.queuePackets, prometheus.CounterValue, packets, interfaceName, queueNumber,
+				collector.queuePackets, prometheus.CounterValue, packets, interfaceName, queueNumber, queueType, namespace,
 			),
 		)
 	}
@@ -190,10 +377,10 @@ func (collector *queueCollector) collectQueueCounters(ctx context.Context, redis
 		return fmt.Errorf("value parse failed: %w", err)
 	}
 
-	if collector.metricFilter.Enabled(queueBytesMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics,
+	if collector.metricFilter.SeriesEnabled(queueBytesMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
 			prometheus.MustNewConstMetric(
-				collector.queueBytes, prometheus.CounterValue, bytes, interfaceName, queueNumber,
+				collector.queueBytes, prometheus.CounterValue, bytes, interfaceName, queueNumber, queueType, namespace,
 			),
 		)
 	}
@@ -203,10 +390,10 @@ func (collector *queueCollector) collectQueueCounters(ctx context.Context, redis
 		return fmt.Errorf("value parse failed: %w", err)
 	}
 
-	if collector.metricFilter.Enabled(queueDroppedPacketsMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics,
+	if collector.metricFilter.SeriesEnabled(queueDroppedPacketsMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
 			prometheus.MustNewConstMetric(
-				collector.queueDroppedPackets, prometheus.CounterValue, droppedPackets, interfaceName, queueNumber,
+				collector.queueDroppedPackets, prometheus.CounterValue, droppedPackets, interfaceName, queueNumber, queueType, namespace,
 			),
 		)
 	}
@@ -216,23 +403,31 @@ func (collector *queueCollector) collectQueueCounters(ctx context.Context, redis
 		return fmt.Errorf("value parse failed: %w", err)
 	}
 
-	if collector.metricFilter.Enabled(queueDroppedBytesMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics,
+	if collector.metricFilter.SeriesEnabled(queueDroppedBytesMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
 			prometheus.MustNewConstMetric(
-				collector.queueDroppedBytes, prometheus.CounterValue, droppedBytes, interfaceName, queueNumber,
+				collector.queueDroppedBytes, prometheus.CounterValue, droppedBytes, interfaceName, queueNumber, queueType, namespace,
 			),
 		)
 	}
 
-	sharedWatermarkBytes, err := parseFloat(counters["SAI_QUEUE_STAT_SHARED_WATERMARK_BYTES"])
+	sharedWatermarkBytes, err := parseFloat(counters[queueSharedWatermarkStat])
 	if err != nil {
 		return fmt.Errorf("value parse failed: %w", err)
 	}
 
-	if collector.metricFilter.Enabled(queueSharedWatermarkBytesMetricName) {
-		collector.cachedMetrics = append(collector.cachedMetrics,
+	if collector.metricFilter.SeriesEnabled(queueSharedWatermarkMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
+			prometheus.MustNewConstMetric(
+				collector.queueSharedWatermark, prometheus.GaugeValue, sharedWatermarkBytes, interfaceName, queueNumber, queueType, namespace,
+			),
+		)
+	}
+
+	if collector.config.legacyWatermarks && collector.metricFilter.SeriesEnabled(queueSharedWatermarkBytesMetricName, "device", interfaceName, "queue", queueNumber, "queue_type", queueType, "namespace", namespace) {
+		*metrics = append(*metrics,
 			prometheus.MustNewConstMetric(
-				collector.queueSharedWatermarkBytes, prometheus.CounterValue, sharedWatermarkBytes, interfaceName, queueNumber,
+				collector.queueSharedWatermarkBytes, prometheus.CounterValue, sharedWatermarkBytes, interfaceName, queueNumber, queueType, namespace,
 			),
 		)
 	}
@@ -240,37 +435,66 @@ func (collector *queueCollector) collectQueueCounters(ctx context.Context, redis
 	return nil
 }
 
-func (collector *queueCollector) collectQueueWatermarks(ctx context.Context, redisClient redis.Client, interfaceName, queueNumber, queueName string) error {
-	var watermarkValue float64
+// collectQueueWatermarks collects the watermarks of every queue, reading each
+// watermark type with one batch. The gauge has one series per type, read from
+// SAI_QUEUE_STAT_SHARED_WATERMARK_BYTES of the <TYPE>_WATERMARKS table, queues
+// without it are skipped. The deprecated family keeps a series per field with
+// its watermark label.
+func (collector *queueCollector) collectQueueWatermarks(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, queues []portIndexEntry, queueTypes map[string]string, namespace string) error {
 	for _, watermarkType := range []string{"USER", "PERSISTENT", "PERIODIC"} {
-		watermarksKey := fmt.Sprintf("%s_WATERMARKS:%s", watermarkType, queueName)
-		watermarks, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", watermarksKey)
+		watermarksKeys := make([]string, 0, len(queues))
+		for _, queue := range queues {
+			watermarksKeys = append(watermarksKeys, fmt.Sprintf("%s_WATERMARKS:%s", watermarkType, queue.oid))
+		}
+
+		watermarks, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", watermarksKeys)
 		if err != nil {
 			return fmt.Errorf("redis read failed: %w", err)
 		}
 
-		for watermarkKey, value := range watermarks {
-			watermarkValue, err = parseFloat(value)
-			if err != nil {
-				return fmt.Errorf("value parse failed: %w", err)
+		typeLabel := strings.ToLower(watermarkType)
+		for i, queue := range queues {
+			queueType := queueTypeLabel(queueTypes[queue.oid])
+
+			if sharedWatermark, ok := watermarks[i][queueSharedWatermarkStat]; ok && collector.metricFilter.SeriesEnabled(queueWatermarkMetricName, "device", queue.device, "queue", queue.index, "queue_type", queueType, "type", typeLabel, "namespace", namespace) {
+				watermarkValue, err := parseFloat(sharedWatermark)
+				if err != nil {
+					return fmt.Errorf("value parse failed: %w", err)
+				}
+
+				*metrics = append(*metrics,
+					prometheus.MustNewConstMetric(
+						collector.queueWatermark, prometheus.GaugeValue, watermarkValue,
+						queue.device, queue.index, queueType, typeLabel, namespace,
+					),
+				)
 			}
 
-			prefixes := []string{"SAI_QUEUE_STAT_", "SAI_INGRESS_PRIORITY_GROUP_STAT_"}
-			suffixes := []string{"_WATERMARK_BYTES"}
-			watermarkLabel := watermarkKey
-			for _, prefix := range prefixes {
-				watermarkLabel = strings.TrimPrefix(watermarkLabel, prefix)
+			if !collector.config.legacyWatermarks {
+				continue
 			}
 
-			for _, suffix := range suffixes {
-				watermarkLabel = strings.TrimSuffix(watermarkLabel, suffix)
+			watermarkKeys := make([]string, 0, len(watermarks[i]))
+			for watermarkKey := range watermarks[i] {
+				watermarkKeys = append(watermarkKeys, watermarkKey)
 			}
+			sort.Strings(watermarkKeys)
+
+			for _, watermarkKey := range watermarkKeys {
+				watermark := watermarkLabel(watermarkKey)
+				if !collector.metricFilter.SeriesEnabled(queueWatermarkBytesMetricName, "device", queue.device, "queue", queue.index, "queue_type", queueType, "type", typeLabel, "watermark", watermark, "namespace", namespace) {
+					continue
+				}
+
+				watermarkValue, err := parseFloat(watermarks[i][watermarkKey])
+				if err != nil {
+					return fmt.Errorf("value parse failed: %w", err)
+				}
 
-			if collector.metricFilter.Enabled(queueWatermarkBytesMetricName) {
-				collector.cachedMetrics = append(collector.cachedMetrics,
+				*metrics = append(*metrics,
 					prometheus.MustNewConstMetric(
 						collector.queueWatermarksBytes, prometheus.CounterValue, watermarkValue,
-						interfaceName, queueNumber, strings.ToLower(watermarkType), strings.ToLower(watermarkLabel),
+						queue.device, queue.index, queueType, typeLabel, watermark, namespace,
 					),
 				)
 			}
@@ -279,3 +503,63 @@ func (collector *queueCollector) collectQueueWatermarks(ctx context.Context, red
 
 	return nil
 }
+
+// watermarksEnabled reports whether any family built from the
+// <TYPE>_WATERMARKS tables is enabled, so their reads can be skipped.
+func (collector *queueCollector) watermarksEnabled() bool {
+	if collector.metricFilter.Enabled(queueWatermarkMetricName) {
+		return true
+	}
+
+	return collector.config.legacyWatermarks && collector.metricFilter.Enabled(queueWatermarkBytesMetricName)
+}
+
+// collectPeriodicInterval collects the interval of CONFIG_DB
+// WATERMARK_TABLE|TELEMETRY_INTERVAL, after which SONiC reads and clears the
+// periodic watermarks. It is in seconds.
+func (collector *queueCollector) collectPeriodicInterval(ctx context.Context, redisClient redis.Client, metrics *[]prometheus.Metric, namespace string) error {
+	telemetry, err := redisClient.HgetAllFromDb(ctx, "CONFIG_DB", "WATERMARK_TABLE|TELEMETRY_INTERVAL")
+	if err != nil {
+		return fmt.Errorf("redis read failed: %w", err)
+	}
+
+	interval := defaultWatermarkTelemetryInterval.Seconds()
+	if value, ok := telemetry["interval"]; ok {
+		interval, err = parseFloat(value)
+		if err != nil {
+			return fmt.Errorf("value parse failed: %w", err)
+		}
+	}
+
+	*metrics = append(*metrics, prometheus.MustNewConstMetric(
+		collector.queuePeriodicInterval, prometheus.GaugeValue, interval, namespace,
+	))
+
+	return nil
+}
+
+// watermarkLabel returns the watermark name of a watermark stat, e.g. shared
+// for SAI_QUEUE_STAT_SHARED_WATERMARK_BYTES.
+func watermarkLabel(watermarkKey string) string {
+	prefixes := []string{"SAI_QUEUE_STAT_", "SAI_INGRESS_PRIORITY_GROUP_STAT_"}
+	suffixes := []string{"_WATERMARK_BYTES"}
+	label := watermarkKey
+	for _, prefix := range prefixes {
+		label = strings.TrimPrefix(label, prefix)
+	}
+
+	for _, suffix := range suffixes {
+		label = strings.TrimSuffix(label, suffix)
+	}
+
+	return strings.ToLower(label)
+}
+
+func loadQueueCollectorConfig(logger *slog.Logger) queueCollectorConfig {
+	return queueCollectorConfig{
+		enabled:          parseBoolEnv(logger, "QUEUE_ENABLED", true),
+		refreshInterval:  parseDurationEnv(logger, "QUEUE_REFRESH_INTERVAL", 15*time.Second),
+		timeout:          parseDurationEnv(logger, "QUEUE_TIMEOUT", 5*time.Second),
+		legacyWatermarks: parseBoolEnv(logger, "QUEUE_LEGACY_WATERMARK_METRICS", true),
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const (
+	redisPoolHitsMetricName             = "sonic_exporter_redis_pool_hits_total"
+	redisPoolMissesMetricName           = "sonic_exporter_redis_pool_misses_total"
+	redisPoolTimeoutsMetricName         = "sonic_exporter_redis_pool_timeouts_total"
+	redisPoolWaitsMetricName            = "sonic_exporter_redis_pool_waits_total"
+	redisPoolConnectionsMetricName      = "sonic_exporter_redis_pool_connections"
+	redisPoolIdleConnectionsMetricName  = "sonic_exporter_redis_pool_idle_connections"
+	redisPoolStaleConnectionsMetricName = "sonic_exporter_redis_pool_stale_connections_total"
+)
+
+type redisPoolCollector struct {
+	poolHits             *prometheus.Desc
+	poolMisses           *prometheus.Desc
+	poolTimeouts         *prometheus.Desc
+	poolWaits            *prometheus.Desc
+	poolConnections      *prometheus.Desc
+	poolIdleConnections  *prometheus.Desc
+	poolStaleConnections *prometheus.Desc
+	logger               *slog.Logger
+	metricFilter         MetricFilter
+	redisClient          redis.Client
+}
+
+func NewRedisPoolCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *redisPoolCollector {
+	const (
+		namespace = "sonic"
+		subsystem = "exporter"
+	)
+
+	return &redisPoolCollector{
+		poolHits: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_hits_total"),
+			"Number of times a free connection was found in the redis pool", []string{"database", "namespace"}, nil),
+		poolMisses: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_misses_total"),
+			"Number of times a free connection was not found in the redis pool", []string{"database", "namespace"}, nil),
+		poolTimeouts: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_timeouts_total"),
+			"Number of times waiting for a redis pool connection timed out", []string{"database", "namespace"}, nil),
+		poolWaits: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_waits_total"),
+			"Number of times a caller had to wait for a redis pool connection", []string{"database", "namespace"}, nil),
+		poolConnections: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_connections"),
+			"Number of open connections in the redis pool", []string{"database", "namespace"}, nil),
+		poolIdleConnections: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_idle_connections"),
+			"Number of idle connections in the redis pool", []string{"database", "namespace"}, nil),
+		poolStaleConnections: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "redis_pool_stale_connections_total"),
+			"Number of stale connections removed from the redis pool", []string{"database", "namespace"}, nil),
+		logger:       logger,
+		metricFilter: metricFilter,
+		redisClient:  redisClient,
+	}
+}
+
+func (collector *redisPoolCollector) Name() string {
+	return "redis_pool"
+}
+
+func (collector *redisPoolCollector) IsEnabled() bool {
+	return true
+}
+
+// Health is always successful, pool statistics are read locally.
+func (collector *redisPoolCollector) Health() CollectorHealth {
+	return CollectorHealth{Success: true, LastRefresh: time.Now()}
+}
+
+// Stop is a no-op, redisPoolCollector has no background refresh.
+func (collector *redisPoolCollector) Stop() {}
+
+func (collector *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.poolHits
+	ch <- collector.poolMisses
+	ch <- collector.poolTimeouts
+	ch <- collector.poolWaits
+	ch <- collector.poolConnections
+	ch <- collector.poolIdleConnections
+	ch <- collector.poolStaleConnections
+}
+
+// Collect reads pool statistics directly on every scrape, it is cheap and does not touch redis.
+func (collector *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range collector.redisClient.PoolStats() {
+		database, namespace := stats.Database, stats.Namespace
+
+		collector.emit(ch, redisPoolHitsMetricName, collector.poolHits, prometheus.CounterValue, float64(stats.Hits), database, namespace)
+		collector.emit(ch, redisPoolMissesMetricName, collector.poolMisses, prometheus.CounterValue, float64(stats.Misses), database, namespace)
+		collector.emit(ch, redisPoolTimeoutsMetricName, collector.poolTimeouts, prometheus.CounterValue, float64(stats.Timeouts), database, namespace)
+		collector.emit(ch, redisPoolWaitsMetricName, collector.poolWaits, prometheus.CounterValue, float64(stats.WaitCount), database, namespace)
+		collector.emit(ch, redisPoolConnectionsMetricName, collector.poolConnections, prometheus.GaugeValue, float64(stats.TotalConns), database, namespace)
+		collector.emit(ch, redisPoolIdleConnectionsMetricName, collector.poolIdleConnections, prometheus.GaugeValue, float64(stats.IdleConns), database, namespace)
+		collector.emit(ch, redisPoolStaleConnectionsMetricName, collector.poolStaleConnections, prometheus.CounterValue, float64(stats.StaleConns), database, namespace)
+	}
+}
+
+func (collector *redisPoolCollector) emit(ch chan<- prometheus.Metric, metricName string, desc *prometheus.Desc, valueType prometheus.ValueType, value float64, database, namespace string) {
+	if !collector.metricFilter.Enabled(metricName) {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(desc, valueType, value, database, namespace)
+}
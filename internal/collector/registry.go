@@ -0,0 +1,262 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+// SonicCollector is implemented by every collector of the exporter.
+type SonicCollector interface {
+	prometheus.Collector
+	Name() string
+	IsEnabled() bool
+	Health() CollectorHealth
+	// Stop ends background refreshes. The collector must not be used afterwards.
+	Stop()
+}
+
+// CollectorHealth is the outcome of the latest refresh of a collector.
+// LastRefresh is zero until the first successful refresh. RefreshInterval is
+// zero for collectors without background refresh.
+type CollectorHealth struct {
+	Success         bool
+	LastRefresh     time.Time
+	RefreshInterval time.Duration
+}
+
+// Ready reports whether the last successful refresh is at most maxIntervals
+// refresh intervals old. Collectors without background refresh are always
+// ready, they refresh on scrape.
+func (health CollectorHealth) Ready(now time.Time, maxIntervals int) bool {
+	if health.RefreshInterval <= 0 {
+		return true
+	}
+	if health.LastRefresh.IsZero() {
+		return false
+	}
+
+	return now.Sub(health.LastRefresh) <= time.Duration(maxIntervals)*health.RefreshInterval
+}
+
+// CollectorOptions are the shared dependencies handed to every collector.
+type CollectorOptions struct {
+	Logger       *slog.Logger
+	MetricFilter MetricFilter
+	RedisClient  redis.Client
+
+	// previous holds the running collectors on reload, so that a new
+	// collector can take over state its first refresh depends on
+	previous []SonicCollector
+}
+
+type collectorFactory struct {
+	name           string
+	defaultEnabled bool
+	// buildOnce collectors are built at startup only. Reload keeps the
+	// running collector and hands it the new metric filter.
+	buildOnce bool
+	// build loads the collector config and creates an enabled collector.
+	build func(options CollectorOptions) SonicCollector
+}
+
+// metricFilterReplacer is implemented by buildOnce collectors.
+type metricFilterReplacer interface {
+	// withMetricFilter returns a copy of the collector using metricFilter.
+	withMetricFilter(metricFilter MetricFilter) SonicCollector
+}
+
+// collectorFactories lists all collectors in registration order. Whether a
+// collector runs is decided by the registry, so configurable collectors are
+// always built with enabled config.
+var collectorFactories = []collectorFactory{
+	{name: "interface", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadInterfaceCollectorConfig(options.Logger)
+		config.enabled = true
+		return newInterfaceCollector(options.Logger, options.MetricFilter, options.RedisClient, config, fecSamplesOf(options.previous))
+	}},
+	{name: "hw", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadHwCollectorConfig(options.Logger)
+		config.enabled = true
+		return newHwCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "crm", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadCrmCollectorConfig(options.Logger)
+		config.enabled = true
+		return newCrmCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "queue", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadQueueCollectorConfig(options.Logger)
+		config.enabled = true
+		return newQueueCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "priority_group", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadPriorityGroupCollectorConfig(options.Logger)
+		config.enabled = true
+		return newPriorityGroupCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "pfcwd", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadPfcwdCollectorConfig(options.Logger)
+		config.enabled = true
+		return newPfcwdCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "buffer_pool", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadBufferPoolCollectorConfig(options.Logger)
+		config.enabled = true
+		return newBufferPoolCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "rif", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadRifCollectorConfig(options.Logger)
+		config.enabled = true
+		return newRifCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "redis_pool", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		return NewRedisPoolCollector(options.Logger, options.MetricFilter, options.RedisClient)
+	}},
+	{name: "lldp", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadLldpCollectorConfig(options.Logger)
+		config.enabled = true
+		return newLldpCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "vlan", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadVlanCollectorConfig(options.Logger)
+		config.enabled = true
+		return newVlanCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "lag", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadLagCollectorConfig(options.Logger)
+		config.enabled = true
+		return newLagCollector(options.Logger, options.MetricFilter, options.RedisClient, config, lagCounterStatesOf(options.previous))
+	}},
+	{name: "fdb", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadFdbCollectorConfig(options.Logger)
+		config.enabled = true
+		return newFdbCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "routing", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadRoutingCollectorConfig(options.Logger)
+		config.enabled = true
+		return newRoutingCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "switch", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadSwitchCollectorConfig(options.Logger)
+		config.enabled = true
+		return newSwitchCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "thermal", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadThermalCollectorConfig(options.Logger)
+		config.enabled = true
+		return newThermalCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "transceiver", defaultEnabled: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadTransceiverCollectorConfig(options.Logger)
+		config.enabled = true
+		return newTransceiverCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "platform_health", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadPlatformHealthCollectorConfig(options.Logger)
+		config.enabled = true
+		return newPlatformHealthCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "system", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadSystemCollectorConfig(options.Logger)
+		config.enabled = true
+		return newSystemCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	{name: "docker", defaultEnabled: false, build: func(options CollectorOptions) SonicCollector {
+		config := loadDockerCollectorConfig(options.Logger)
+		config.enabled = true
+		return newDockerCollector(options.Logger, options.MetricFilter, options.RedisClient, config)
+	}},
+	// FRR parses the global kingpin flags of frr_exporter, doing that again
+	// on reload would reset them under the running exporter
+	{name: "frr", defaultEnabled: false, buildOnce: true, build: func(options CollectorOptions) SonicCollector {
+		config := loadFrrCollectorConfig(options.Logger)
+		config.enabled = true
+		return newFrrCollector(options.Logger, options.MetricFilter, config)
+	}},
+}
+
+// collectorEnabledEnv returns the env var enabling a collector, e.g. LLDP_ENABLED.
+func collectorEnabledEnv(name string) string {
+	return strings.ToUpper(name) + "_ENABLED"
+}
+
+type collectorFlag struct {
+	enabled   *bool
+	setByUser bool
+}
+
+// CollectorFlags holds the --[no-]collector.<name> flag of every collector.
+type CollectorFlags map[string]*collectorFlag
+
+// AddCollectorFlags adds a --[no-]collector.<name> flag for every registered
+// collector. An explicit flag overrides <NAME>_ENABLED.
+func AddCollectorFlags(app *kingpin.Application) CollectorFlags {
+	flags := make(CollectorFlags, len(collectorFactories))
+
+	for _, factory := range collectorFactories {
+		flag := &collectorFlag{}
+		help := fmt.Sprintf("Enable the %s collector, overrides %s (default: %t).", factory.name, collectorEnabledEnv(factory.name), factory.defaultEnabled)
+		flag.enabled = app.Flag("collector."+factory.name, help).IsSetByUser(&flag.setByUser).Bool()
+		flags[factory.name] = flag
+	}
+
+	return flags
+}
+
+func (flags CollectorFlags) enabled(logger *slog.Logger, factory collectorFactory) bool {
+	if flag, ok := flags[factory.name]; ok && flag.setByUser {
+		return *flag.enabled
+	}
+
+	return parseBoolEnv(logger, collectorEnabledEnv(factory.name), factory.defaultEnabled)
+}
+
+// NewCollectors builds every enabled collector in registration order.
+func NewCollectors(options CollectorOptions, flags CollectorFlags) []SonicCollector {
+	return buildCollectors(options, flags, nil, false)
+}
+
+// reloadCollectors builds every enabled collector again, except buildOnce
+// collectors. Those are taken from previous with the new metric filter, or
+// stay disabled when they did not run before.
+func reloadCollectors(options CollectorOptions, flags CollectorFlags, previous []SonicCollector) []SonicCollector {
+	return buildCollectors(options, flags, previous, true)
+}
+
+func buildCollectors(options CollectorOptions, flags CollectorFlags, previous []SonicCollector, reload bool) []SonicCollector {
+	collectors := make([]SonicCollector, 0, len(collectorFactories))
+	options.previous = previous
+
+	for _, factory := range collectorFactories {
+		if reload && factory.buildOnce {
+			for _, collector := range previous {
+				if replacer, ok := collector.(metricFilterReplacer); ok && collector.Name() == factory.name {
+					collectors = append(collectors, replacer.withMetricFilter(options.MetricFilter))
+				}
+			}
+			continue
+		}
+
+		if !flags.enabled(options.Logger, factory) {
+			options.Logger.Info("Collector is disabled", "collector", factory.name)
+			continue
+		}
+
+		collector := factory.build(options)
+		if !collector.IsEnabled() {
+			// Collectors may still turn themselves off, e.g. when FRR flags fail to parse
+			continue
+		}
+
+		collectors = append(collectors, collector)
+	}
+
+	return collectors
+}
@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/promslog"
+)
+
+func newTestCollectors(t *testing.T, args ...string) map[string]SonicCollector {
+	t.Helper()
+
+	app := kingpin.New("test", "")
+	flags := AddCollectorFlags(app)
+	if _, err := app.Parse(args); err != nil {
+		t.Fatalf("Parse(%q) error = %v", args, err)
+	}
+
+	logger := promslog.New(&promslog.Config{})
+	collectors := map[string]SonicCollector{}
+	for _, collector := range NewCollectors(CollectorOptions{Logger: logger, MetricFilter: newTestMetricFilter(t, logger), RedisClient: testRedisClient}, flags) {
+		if _, ok := collectors[collector.Name()]; ok {
+			t.Fatalf("collector %s built twice", collector.Name())
+		}
+		collectors[collector.Name()] = collector
+	}
+
+	return collectors
+}
+
+func TestNewCollectors(t *testing.T) {
+	t.Setenv("QUEUE_ENABLED", "false")
+	t.Setenv("SYSTEM_ENABLED", "false")
+	t.Setenv("DOCKER_ENABLED", "false")
+
+	collectors := newTestCollectors(t, "--no-collector.lldp", "--collector.system")
+
+	for name, want := range map[string]bool{
+		"interface":  true,
+		"redis_pool": true,
+		"queue":      false, // QUEUE_ENABLED=false
+		"lldp":       false, // flag overrides LLDP_ENABLED=true
+		"system":     true,  // flag overrides SYSTEM_ENABLED=false
+		"docker":     false,
+		"routing":    true, // ROUTING_ENABLED=true
+		"frr":        false,
+	} {
+		if _, got := collectors[name]; got != want {
+			t.Errorf("collector %s built = %t, want %t", name, got, want)
+		}
+	}
+
+	for _, factory := range collectorFactories {
+		if collector, ok := collectors[factory.name]; ok && collector.Name() != factory.name {
+			t.Errorf("collector registered as %s is named %s", factory.name, collector.Name())
+		}
+	}
+}
+
+func TestCollectorHealth(t *testing.T) {
+	collectors := newTestCollectors(t)
+
+	// Background refresh collectors refresh once during construction
+	for _, name := range []string{"interface", "hw", "crm", "queue", "priority_group", "vlan"} {
+		if health := collectors[name].Health(); !health.Success || health.LastRefresh.IsZero() || health.RefreshInterval == 0 {
+			t.Errorf("%s health = %+v, want successful refresh", name, health)
+		}
+	}
+}
+
+func TestCollectorHealthReady(t *testing.T) {
+	now := time.Now()
+
+	for _, testCase := range []struct {
+		name   string
+		health CollectorHealth
+		want   bool
+	}{
+		{name: "scrape time collector", health: CollectorHealth{}, want: true},
+		{name: "never refreshed", health: CollectorHealth{RefreshInterval: time.Minute}, want: false},
+		{name: "recent refresh", health: CollectorHealth{LastRefresh: now.Add(-2 * time.Minute), RefreshInterval: time.Minute}, want: true},
+		{name: "failing but recent", health: CollectorHealth{Success: false, LastRefresh: now.Add(-time.Minute), RefreshInterval: time.Minute}, want: true},
+		{name: "stale refresh", health: CollectorHealth{Success: true, LastRefresh: now.Add(-4 * time.Minute), RefreshInterval: time.Minute}, want: false},
+	} {
+		if got := testCase.health.Ready(now, 3); got != testCase.want {
+			t.Errorf("%s: Ready() = %t, want %t", testCase.name, got, testCase.want)
+		}
+	}
+}
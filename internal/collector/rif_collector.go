@@ -0,0 +1,323 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vinted/sonic-exporter/pkg/redis"
+)
+
+const (
+	rifBytesMetricName            = "sonic_rif_bytes_total"
+	rifPacketsMetricName          = "sonic_rif_packets_total"
+	rifErrorBytesMetricName       = "sonic_rif_error_bytes_total"
+	rifErrorPacketsMetricName     = "sonic_rif_error_packets_total"
+	rifScrapeDurationMetricName   = "sonic_rif_scrape_duration_seconds"
+	rifCollectorSuccessMetricName = "sonic_rif_collector_success"
+	rifCacheAgeMetricName         = "sonic_rif_cache_age_seconds"
+	rifEntriesSkippedMetricName   = "sonic_rif_entries_skipped"
+	rifEntriesTruncatedMetricName = "sonic_rif_entries_truncated"
+)
+
+// rifTypes maps COUNTERS_RIF_TYPE_MAP values to the rif_type label. Other
+// values are "unknown", so the label stays bounded.
+var rifTypes = map[string]string{
+	"SAI_ROUTER_INTERFACE_TYPE_PORT":        "port",
+	"SAI_ROUTER_INTERFACE_TYPE_VLAN":        "vlan",
+	"SAI_ROUTER_INTERFACE_TYPE_LOOPBACK":    "loopback",
+	"SAI_ROUTER_INTERFACE_TYPE_SUB_PORT":    "sub_port",
+	"SAI_ROUTER_INTERFACE_TYPE_BRIDGE":      "bridge",
+	"SAI_ROUTER_INTERFACE_TYPE_MPLS_ROUTER": "mpls_router",
+	"SAI_ROUTER_INTERFACE_TYPE_QINQ_PORT":   "qinq_port",
+}
+
+type rifCollectorConfig struct {
+	enabled         bool
+	refreshInterval time.Duration
+	timeout         time.Duration
+	maxInterfaces   int
+}
+
+type rifCollector struct {
+	bytes                  *prometheus.Desc
+	packets                *prometheus.Desc
+	errorBytes             *prometheus.Desc
+	errorPackets           *prometheus.Desc
+	scrapeDuration         *prometheus.Desc
+	scrapeCollectorSuccess *prometheus.Desc
+	cacheAge               *prometheus.Desc
+	entriesSkipped         *prometheus.Desc
+	entriesTruncated       *prometheus.Desc
+
+	logger       *slog.Logger
+	metricFilter MetricFilter
+	redisClient  redis.Client
+	config       rifCollectorConfig
+	stop         chan struct{}
+
+	mu                 sync.RWMutex
+	snapshots          map[string]namespaceSnapshot
+	lastScrapeDuration float64
+}
+
+func NewRifCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *rifCollector {
+	return newRifCollector(logger, metricFilter, redisClient, loadRifCollectorConfig(logger))
+}
+
+func newRifCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config rifCollectorConfig) *rifCollector {
+	const (
+		namespace = "sonic"
+		subsystem = "rif"
+	)
+
+	labels := []string{"rif", "rif_type", "direction", "namespace"}
+
+	collector := &rifCollector{
+		bytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bytes_total"),
+			"Number of bytes on a router interface by direction: rx, tx", labels, nil),
+		packets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "packets_total"),
+			"Number of packets on a router interface by direction: rx, tx", labels, nil),
+		errorBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "error_bytes_total"),
+			"Number of error bytes on a router interface by direction: rx, tx", labels, nil),
+		errorPackets: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "error_packets_total"),
+			"Number of error packets on a router interface by direction: rx, tx", labels, nil),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
+			"Time it took for exporter to refresh RIF metrics", nil, nil),
+		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
+			"Whether RIF collector succeeded", []string{"namespace"}, nil),
+		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
+			"Age of latest RIF cache refresh", nil, nil),
+		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
+			"Number of router interfaces skipped during latest refresh", nil, nil),
+		entriesTruncated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_truncated"),
+			"Whether RIF collection hit max interfaces limit (1=yes, 0=no)", nil, nil),
+		logger:       logger,
+		metricFilter: metricFilter,
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
+	}
+
+	if !collector.config.enabled {
+		collector.logger.Info("RIF collector is disabled")
+		return collector
+	}
+
+	collector.refreshMetrics()
+	go collector.refreshLoop()
+
+	return collector
+}
+
+func (collector *rifCollector) Name() string {
+	return "rif"
+}
+
+func (collector *rifCollector) IsEnabled() bool {
+	return collector.config.enabled
+}
+
+func (collector *rifCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *rifCollector) Stop() {
+	close(collector.stop)
+}
+
+func (collector *rifCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.bytes
+	ch <- collector.packets
+	ch <- collector.errorBytes
+	ch <- collector.errorPackets
+	ch <- collector.scrapeDuration
+	ch <- collector.scrapeCollectorSuccess
+	ch <- collector.cacheAge
+	ch <- collector.entriesSkipped
+	ch <- collector.entriesTruncated
+}
+
+func (collector *rifCollector) Collect(ch chan<- prometheus.Metric) {
+	if !collector.config.enabled {
+		return
+	}
+
+	collector.mu.RLock()
+	snapshots := collector.snapshots
+	lastScrapeDuration := collector.lastScrapeDuration
+	collector.mu.RUnlock()
+
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled(rifCollectorSuccessMetricName) {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
+	}
+
+	lastSkippedEntries, lastTruncated, cacheAge := summarizeNamespaces(snapshots)
+
+	if collector.metricFilter.Enabled(rifScrapeDurationMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
+	}
+	if collector.metricFilter.Enabled(rifCacheAgeMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
+	}
+	if collector.metricFilter.Enabled(rifEntriesSkippedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
+	}
+	if collector.metricFilter.Enabled(rifEntriesTruncatedMetricName) {
+		ch <- prometheus.MustNewConstMetric(collector.entriesTruncated, prometheus.GaugeValue, lastTruncated)
+	}
+}
+
+func (collector *rifCollector) refreshLoop() {
+	ticker := time.NewTicker(collector.config.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
+	}
+}
+
+func (collector *rifCollector) refreshMetrics() {
+	start := time.Now()
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "RIF", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		return collector.scrapeMetrics(ctx, redisClient, namespace)
+	})
+	scrapeDuration := time.Since(start).Seconds()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.lastScrapeDuration = scrapeDuration
+	collector.snapshots = snapshots
+}
+
+// scrapeMetrics collects the counters of the router interfaces listed in
+// COUNTERS_RIF_NAME_MAP, sorted by name and cut at maxInterfaces. A router
+// interface with an unparsable counter is skipped.
+func (collector *rifCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+	rifOids, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_RIF_NAME_MAP")
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	rifTypeMap, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "COUNTERS_RIF_TYPE_MAP")
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	rifs := make([]string, 0, len(rifOids))
+	for rif := range rifOids {
+		rifs = append(rifs, rif)
+	}
+	sort.Strings(rifs)
+
+	truncated := 0.0
+	if len(rifs) > collector.config.maxInterfaces {
+		rifs = rifs[:collector.config.maxInterfaces]
+		truncated = 1
+	}
+
+	counterKeys := make([]string, 0, len(rifs))
+	for _, rif := range rifs {
+		counterKeys = append(counterKeys, "COUNTERS:"+rifOids[rif])
+	}
+
+	counters, err := redisClient.HgetAllManyFromDb(ctx, "COUNTERS_DB", counterKeys)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("redis read failed: %w", err)
+	}
+
+	metrics := []prometheus.Metric{}
+	skippedEntries := 0
+	for i, rif := range rifs {
+		rifType, ok := rifTypes[rifTypeMap[rifOids[rif]]]
+		if !ok {
+			rifType = "unknown"
+		}
+
+		rifMetrics, err := collector.collectRifCounters(rif, rifType, counters[i], namespace)
+		if err != nil {
+			collector.logger.Debug("Skipping router interface", "rif", rif, "namespace", namespace, "error", err)
+			skippedEntries++
+			continue
+		}
+
+		metrics = append(metrics, rifMetrics...)
+	}
+
+	return metrics, skippedEntries, truncated, nil
+}
+
+// collectRifCounters builds the metrics of one router interface from the
+// SAI_ROUTER_INTERFACE_STAT_{IN,OUT}_* counters. Counters it does not have are
+// not exported.
+func (collector *rifCollector) collectRifCounters(rif, rifType string, counters map[string]string, namespace string) ([]prometheus.Metric, error) {
+	stats := []struct {
+		metricName string
+		stat       string
+		desc       *prometheus.Desc
+	}{
+		{rifBytesMetricName, "OCTETS", collector.bytes},
+		{rifPacketsMetricName, "PACKETS", collector.packets},
+		{rifErrorBytesMetricName, "ERROR_OCTETS", collector.errorBytes},
+		{rifErrorPacketsMetricName, "ERROR_PACKETS", collector.errorPackets},
+	}
+
+	metrics := []prometheus.Metric{}
+	for _, direction := range []string{"rx", "tx"} {
+		prefix := "SAI_ROUTER_INTERFACE_STAT_IN_"
+		if direction == "tx" {
+			prefix = "SAI_ROUTER_INTERFACE_STAT_OUT_"
+		}
+
+		for _, stat := range stats {
+			value, ok := counters[prefix+stat.stat]
+			if !ok {
+				continue
+			}
+
+			parsed, err := parseFloat(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s parse failed: %w", strings.ToLower(stat.stat), err)
+			}
+
+			if collector.metricFilter.SeriesEnabled(stat.metricName, "rif", rif, "rif_type", rifType, "direction", direction, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(stat.desc, prometheus.CounterValue, parsed, rif, rifType, direction, namespace))
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+func loadRifCollectorConfig(logger *slog.Logger) rifCollectorConfig {
+	return rifCollectorConfig{
+		enabled:         parseBoolEnv(logger, "RIF_ENABLED", false),
+		refreshInterval: parseDurationEnv(logger, "RIF_REFRESH_INTERVAL", 15*time.Second),
+		timeout:         parseDurationEnv(logger, "RIF_TIMEOUT", 2*time.Second),
+		maxInterfaces:   parseIntEnv(logger, "RIF_MAX_INTERFACES", 1024),
+	}
+}
@@ -33,18 +33,20 @@ type routingCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       routingCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
-	cachedMetrics      []prometheus.Metric
-	lastSuccess        float64
+	snapshots          map[string]namespaceSnapshot
 	lastScrapeDuration float64
-	lastSkippedEntries float64
-	lastTruncated      float64
-	lastRefreshTime    time.Time
 }
 
-func NewRoutingCollector(logger *slog.Logger, metricFilter MetricFilter) *routingCollector {
+func NewRoutingCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *routingCollector {
+	return newRoutingCollector(logger, metricFilter, redisClient, loadRoutingCollectorConfig(logger))
+}
+
+func newRoutingCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config routingCollectorConfig) *routingCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "routing"
@@ -52,9 +54,9 @@ func NewRoutingCollector(logger *slog.Logger, metricFilter MetricFilter) *routin
 
 	collector := &routingCollector{
 		neighborEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "neighbor_entries"),
-			"Number of neighbor entries by interface and address family", []string{"interface", "family"}, nil),
+			"Number of neighbor entries by interface and address family", []string{"interface", "family", "namespace"}, nil),
 		routeEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "route_entries"),
-			"Number of route entries by address family and protocol", []string{"family", "protocol"}, nil),
+			"Number of route entries by address family and protocol", []string{"family", "protocol", "namespace"}, nil),
 		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
 			"Number of routing entries skipped during latest refresh", nil, nil),
 		entriesTruncated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_truncated"),
@@ -62,19 +64,14 @@ func NewRoutingCollector(logger *slog.Logger, metricFilter MetricFilter) *routin
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
 			"Time it took for exporter to refresh routing metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether routing collector succeeded", nil, nil),
+			"Whether routing collector succeeded", []string{"namespace"}, nil),
 		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
 			"Age of latest routing cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config: routingCollectorConfig{
-			enabled:         parseBoolEnv(logger, "ROUTING_ENABLED", false),
-			refreshInterval: parseDurationEnv(logger, "ROUTING_REFRESH_INTERVAL", 60*time.Second),
-			timeout:         parseDurationEnv(logger, "ROUTING_TIMEOUT", 2*time.Second),
-			maxNeighbors:    parseIntEnv(logger, "ROUTING_MAX_NEIGHBORS", 50000),
-			maxRoutes:       parseIntEnv(logger, "ROUTING_MAX_ROUTES", 200000),
-			redisScanCount:  256,
-		},
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -92,6 +89,21 @@ func (collector *routingCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *routingCollector) Name() string {
+	return "routing"
+}
+
+func (collector *routingCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *routingCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *routingCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.neighborEntries
 	ch <- collector.routeEntries
@@ -108,22 +120,20 @@ func (collector *routingCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	collector.mu.RLock()
-	cachedMetrics := append([]prometheus.Metric{}, collector.cachedMetrics...)
+	snapshots := collector.snapshots
 	lastScrapeDuration := collector.lastScrapeDuration
-	lastSuccess := collector.lastSuccess
-	lastSkippedEntries := collector.lastSkippedEntries
-	lastTruncated := collector.lastTruncated
-	lastRefreshTime := collector.lastRefreshTime
 	collector.mu.RUnlock()
 
-	for _, metric := range cachedMetrics {
-		ch <- metric
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled("sonic_routing_collector_success") {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	cacheAge := 0.0
-	if !lastRefreshTime.IsZero() {
-		cacheAge = time.Since(lastRefreshTime).Seconds()
-	}
+	lastSkippedEntries, lastTruncated, cacheAge := summarizeNamespaces(snapshots)
 
 	if collector.metricFilter.Enabled("sonic_routing_entries_skipped") {
 		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
@@ -134,9 +144,6 @@ func (collector *routingCollector) Collect(ch chan<- prometheus.Metric) {
 	if collector.metricFilter.Enabled("sonic_routing_scrape_duration_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled("sonic_routing_collector_success") {
-		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
-	}
 	if collector.metricFilter.Enabled("sonic_routing_cache_age_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
@@ -146,44 +153,34 @@ func (collector *routingCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
 func (collector *routingCollector) refreshMetrics() {
 	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
-	defer cancel()
 
-	metrics, skippedEntries, truncated, err := collector.scrapeMetrics(ctx)
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "routing", collector.redisClient, collector.config.timeout, previous, collector.scrapeMetrics)
 	scrapeDuration := time.Since(start).Seconds()
 
 	collector.mu.Lock()
 	defer collector.mu.Unlock()
 
 	collector.lastScrapeDuration = scrapeDuration
-
-	if err != nil {
-		collector.lastSuccess = 0
-		collector.logger.Error("Error refreshing routing metrics", "error", err)
-		return
-	}
-
-	collector.cachedMetrics = metrics
-	collector.lastSkippedEntries = float64(skippedEntries)
-	collector.lastTruncated = truncated
-	collector.lastSuccess = 1
-	collector.lastRefreshTime = time.Now()
+	collector.snapshots = snapshots
 }
 
-func (collector *routingCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
-
+func (collector *routingCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
 	neighborKeys, err := redisClient.ScanKeysFromDb(ctx, "APPL_DB", "NEIGH_TABLE:*", collector.config.redisScanCount)
 	if err != nil {
 		return nil, 0, 0, fmt.Errorf("failed to scan neighbor keys: %w", err)
@@ -202,13 +199,20 @@ func (collector *routingCollector) scrapeMetrics(ctx context.Context) ([]prometh
 	skippedEntries := 0
 	truncated := 0.0
 
-	for index, neighborKey := range neighborKeys {
-		if index >= collector.config.maxNeighbors {
-			truncated = 1
-			skippedEntries += len(neighborKeys) - index
-			break
-		}
+	if len(neighborKeys) > collector.config.maxNeighbors {
+		truncated = 1
+		skippedEntries += len(neighborKeys) - collector.config.maxNeighbors
+		neighborKeys = neighborKeys[:collector.config.maxNeighbors]
+	}
+	if len(routeKeys) > collector.config.maxRoutes {
+		truncated = 1
+		skippedEntries += len(routeKeys) - collector.config.maxRoutes
+		routeKeys = routeKeys[:collector.config.maxRoutes]
+	}
 
+	neighborParts := make([][]string, 0, len(neighborKeys))
+	neighborEntryKeys := make([]string, 0, len(neighborKeys))
+	for _, neighborKey := range neighborKeys {
 		suffix, err := parseKeySuffix(neighborKey, "NEIGH_TABLE:")
 		if err != nil {
 			skippedEntries++
@@ -220,10 +224,17 @@ func (collector *routingCollector) scrapeMetrics(ctx context.Context) ([]prometh
 			continue
 		}
 
-		neighborData, err := redisClient.HgetAllFromDb(ctx, "APPL_DB", neighborKey)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read neighbor entry %s: %w", neighborKey, err)
-		}
+		neighborParts = append(neighborParts, parts)
+		neighborEntryKeys = append(neighborEntryKeys, neighborKey)
+	}
+
+	neighborEntries, err := redisClient.HgetAllManyFromDb(ctx, "APPL_DB", neighborEntryKeys)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read neighbor entries: %w", err)
+	}
+
+	for index, parts := range neighborParts {
+		neighborData := neighborEntries[index]
 		if len(neighborData) == 0 {
 			skippedEntries++
 			continue
@@ -237,23 +248,26 @@ func (collector *routingCollector) scrapeMetrics(ctx context.Context) ([]prometh
 		neighborCounts[parts[0]+"|"+family]++
 	}
 
-	for index, routeKey := range routeKeys {
-		if index >= collector.config.maxRoutes {
-			truncated = 1
-			skippedEntries += len(routeKeys) - index
-			break
-		}
-
+	routePrefixes := make([]string, 0, len(routeKeys))
+	routeEntryKeys := make([]string, 0, len(routeKeys))
+	for _, routeKey := range routeKeys {
 		prefix, err := parseKeySuffix(routeKey, "ROUTE_TABLE:")
 		if err != nil {
 			skippedEntries++
 			continue
 		}
 
-		routeData, err := redisClient.HgetAllFromDb(ctx, "APPL_DB", routeKey)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read route entry %s: %w", routeKey, err)
-		}
+		routePrefixes = append(routePrefixes, prefix)
+		routeEntryKeys = append(routeEntryKeys, routeKey)
+	}
+
+	routeEntries, err := redisClient.HgetAllManyFromDb(ctx, "APPL_DB", routeEntryKeys)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read route entries: %w", err)
+	}
+
+	for index, prefix := range routePrefixes {
+		routeData := routeEntries[index]
 		if len(routeData) == 0 {
 			skippedEntries++
 			continue
@@ -271,16 +285,27 @@ func (collector *routingCollector) scrapeMetrics(ctx context.Context) ([]prometh
 	for _, key := range sortedMapKeys(neighborCounts) {
 		parts := strings.Split(key, "|")
 		if collector.metricFilter.Enabled("sonic_routing_neighbor_entries") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.neighborEntries, prometheus.GaugeValue, neighborCounts[key], parts[0], parts[1]))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.neighborEntries, prometheus.GaugeValue, neighborCounts[key], parts[0], parts[1], namespace))
 		}
 	}
 
 	for _, key := range sortedMapKeys(routeCounts) {
 		parts := strings.Split(key, "|")
 		if collector.metricFilter.Enabled("sonic_routing_route_entries") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.routeEntries, prometheus.GaugeValue, routeCounts[key], parts[0], parts[1]))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.routeEntries, prometheus.GaugeValue, routeCounts[key], parts[0], parts[1], namespace))
 		}
 	}
 
 	return metrics, skippedEntries, truncated, nil
 }
+
+func loadRoutingCollectorConfig(logger *slog.Logger) routingCollectorConfig {
+	return routingCollectorConfig{
+		enabled:         parseBoolEnv(logger, "ROUTING_ENABLED", false),
+		refreshInterval: parseDurationEnv(logger, "ROUTING_REFRESH_INTERVAL", 60*time.Second),
+		timeout:         parseDurationEnv(logger, "ROUTING_TIMEOUT", 2*time.Second),
+		maxNeighbors:    parseIntEnv(logger, "ROUTING_MAX_NEIGHBORS", 50000),
+		maxRoutes:       parseIntEnv(logger, "ROUTING_MAX_ROUTES", 200000),
+		redisScanCount:  256,
+	}
+}
@@ -36,7 +36,9 @@ type switchCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       switchCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
 	cachedMetrics      []prometheus.Metric
@@ -47,7 +49,11 @@ type switchCollector struct {
 	lastRefreshTime    time.Time
 }
 
-func NewSwitchCollector(logger *slog.Logger, metricFilter MetricFilter) *switchCollector {
+func NewSwitchCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *switchCollector {
+	return newSwitchCollector(logger, metricFilter, redisClient, loadSwitchCollectorConfig(logger))
+}
+
+func newSwitchCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config switchCollectorConfig) *switchCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "switch"
@@ -80,13 +86,9 @@ func NewSwitchCollector(logger *slog.Logger, metricFilter MetricFilter) *switchC
 			"Age of latest switch cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config: switchCollectorConfig{
-			enabled:         parseBoolEnv(logger, "SWITCH_ENABLED", true),
-			refreshInterval: parseDurationEnv(logger, "SWITCH_REFRESH_INTERVAL", 60*time.Second),
-			timeout:         parseDurationEnv(logger, "SWITCH_TIMEOUT", 2*time.Second),
-			maxEntries:      parseIntEnv(logger, "SWITCH_MAX_ENTRIES", 16),
-			redisScanCount:  32,
-		},
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -117,6 +119,21 @@ func (collector *switchCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.cacheAge
 }
 
+func (collector *switchCollector) Name() string {
+	return "switch"
+}
+
+func (collector *switchCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *switchCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *switchCollector) Collect(ch chan<- prometheus.Metric) {
 	if !collector.config.enabled {
 		return
@@ -159,8 +176,13 @@ func (collector *switchCollector) Collect(ch chan<- prometheus.Metric) {
 func (collector *switchCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -187,11 +209,7 @@ func (collector *switchCollector) refreshMetrics() {
 }
 
 func (collector *switchCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	switchKeys, err := redisClient.ScanKeysFromDb(ctx, "APPL_DB", "SWITCH_TABLE:*", collector.config.redisScanCount)
 	if err != nil {
@@ -250,3 +268,13 @@ func (collector *switchCollector) scrapeMetrics(ctx context.Context) ([]promethe
 
 	return metrics, skippedEntries, truncated, nil
 }
+
+func loadSwitchCollectorConfig(logger *slog.Logger) switchCollectorConfig {
+	return switchCollectorConfig{
+		enabled:         parseBoolEnv(logger, "SWITCH_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "SWITCH_REFRESH_INTERVAL", 60*time.Second),
+		timeout:         parseDurationEnv(logger, "SWITCH_TIMEOUT", 2*time.Second),
+		maxEntries:      parseIntEnv(logger, "SWITCH_MAX_ENTRIES", 16),
+		redisScanCount:  32,
+	}
+}
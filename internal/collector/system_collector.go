@@ -73,7 +73,9 @@ type systemCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       systemCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
 	cachedMetrics      []prometheus.Metric
@@ -113,7 +115,11 @@ func (b *limitedOutputBuffer) String() string {
 	return b.buf.String()
 }
 
-func NewSystemCollector(logger *slog.Logger, metricFilter MetricFilter) *systemCollector {
+func NewSystemCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *systemCollector {
+	return newSystemCollector(logger, metricFilter, redisClient, loadSystemCollectorConfig(logger))
+}
+
+func newSystemCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config systemCollectorConfig) *systemCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "system"
@@ -134,7 +140,9 @@ func NewSystemCollector(logger *slog.Logger, metricFilter MetricFilter) *systemC
 			"Age of latest system cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config:       loadSystemCollectorConfig(logger),
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -152,6 +160,21 @@ func (collector *systemCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *systemCollector) Name() string {
+	return "system"
+}
+
+func (collector *systemCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *systemCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *systemCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.identityInfo
 	ch <- collector.softwareInfo
@@ -197,8 +220,13 @@ func (collector *systemCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -291,12 +319,7 @@ func (collector *systemCollector) scrapeMetrics(ctx context.Context) ([]promethe
 }
 
 func (collector *systemCollector) loadFromRedis(ctx context.Context, metadata *systemMetadata) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		collector.logger.Debug("System redis source unavailable", "error", err)
-		return
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	deviceMetadata, err := redisClient.HgetAllFromDb(ctx, "CONFIG_DB", "DEVICE_METADATA|localhost")
 	if err != nil {
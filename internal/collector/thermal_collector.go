@@ -32,7 +32,9 @@ type thermalCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       thermalCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
 	cachedMetrics      []prometheus.Metric
@@ -42,7 +44,11 @@ type thermalCollector struct {
 	lastRefreshTime    time.Time
 }
 
-func NewThermalCollector(logger *slog.Logger, metricFilter MetricFilter) *thermalCollector {
+func NewThermalCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *thermalCollector {
+	return newThermalCollector(logger, metricFilter, redisClient, loadThermalCollectorConfig(logger))
+}
+
+func newThermalCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config thermalCollectorConfig) *thermalCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "thermal"
@@ -67,12 +73,9 @@ func NewThermalCollector(logger *slog.Logger, metricFilter MetricFilter) *therma
 			"Age of latest thermal cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config: thermalCollectorConfig{
-			enabled:         parseBoolEnv(logger, "THERMAL_ENABLED", true),
-			refreshInterval: parseDurationEnv(logger, "THERMAL_REFRESH_INTERVAL", 60*time.Second),
-			timeout:         parseDurationEnv(logger, "THERMAL_TIMEOUT", 2*time.Second),
-			redisScanCount:  32,
-		},
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -99,6 +102,21 @@ func (collector *thermalCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.cacheAge
 }
 
+func (collector *thermalCollector) Name() string {
+	return "thermal"
+}
+
+func (collector *thermalCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return CollectorHealth{Success: collector.lastSuccess == 1, LastRefresh: collector.lastRefreshTime, RefreshInterval: collector.config.refreshInterval}
+}
+
+func (collector *thermalCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *thermalCollector) Collect(ch chan<- prometheus.Metric) {
 	if !collector.config.enabled {
 		return
@@ -137,8 +155,13 @@ func (collector *thermalCollector) Collect(ch chan<- prometheus.Metric) {
 func (collector *thermalCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
@@ -164,11 +187,7 @@ func (collector *thermalCollector) refreshMetrics() {
 }
 
 func (collector *thermalCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
+	redisClient := collector.redisClient
 
 	metrics := []prometheus.Metric{}
 	skippedEntries := 0
@@ -219,3 +238,12 @@ func (collector *thermalCollector) scrapeMetrics(ctx context.Context) ([]prometh
 
 	return metrics, skippedEntries, nil
 }
+
+func loadThermalCollectorConfig(logger *slog.Logger) thermalCollectorConfig {
+	return thermalCollectorConfig{
+		enabled:         parseBoolEnv(logger, "THERMAL_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "THERMAL_REFRESH_INTERVAL", 60*time.Second),
+		timeout:         parseDurationEnv(logger, "THERMAL_TIMEOUT", 2*time.Second),
+		redisScanCount:  32,
+	}
+}
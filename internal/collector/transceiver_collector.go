@@ -12,6 +12,34 @@ import (
 	"github.com/vinted/sonic-exporter/pkg/redis"
 )
 
+// STATE_DB tables read for every transceiver port, indexed by the
+// transceiver*Table constants.
+const (
+	transceiverStatusTable = iota
+	transceiverDomFlagTable
+	transceiverDomFlagChangeCountTable
+	transceiverDomFlagSetTimeTable
+	transceiverDomFlagClearTimeTable
+	transceiverStatusFlagTable
+	transceiverStatusFlagChangeCountTable
+	transceiverStatusFlagSetTimeTable
+	transceiverStatusFlagClearTimeTable
+	transceiverDomThresholdTable
+)
+
+var transceiverTables = []string{
+	transceiverStatusTable:                "TRANSCEIVER_STATUS",
+	transceiverDomFlagTable:               "TRANSCEIVER_DOM_FLAG",
+	transceiverDomFlagChangeCountTable:    "TRANSCEIVER_DOM_FLAG_CHANGE_COUNT",
+	transceiverDomFlagSetTimeTable:        "TRANSCEIVER_DOM_FLAG_SET_TIME",
+	transceiverDomFlagClearTimeTable:      "TRANSCEIVER_DOM_FLAG_CLEAR_TIME",
+	transceiverStatusFlagTable:            "TRANSCEIVER_STATUS_FLAG",
+	transceiverStatusFlagChangeCountTable: "TRANSCEIVER_STATUS_FLAG_CHANGE_COUNT",
+	transceiverStatusFlagSetTimeTable:     "TRANSCEIVER_STATUS_FLAG_SET_TIME",
+	transceiverStatusFlagClearTimeTable:   "TRANSCEIVER_STATUS_FLAG_CLEAR_TIME",
+	transceiverDomThresholdTable:          "TRANSCEIVER_DOM_THRESHOLD",
+}
+
 type transceiverCollectorConfig struct {
 	enabled         bool
 	refreshInterval time.Duration
@@ -40,18 +68,20 @@ type transceiverCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       transceiverCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
-	cachedMetrics      []prometheus.Metric
-	lastSuccess        float64
+	snapshots          map[string]namespaceSnapshot
 	lastScrapeDuration float64
-	lastSkippedEntries float64
-	lastTruncated      float64
-	lastRefreshTime    time.Time
 }
 
-func NewTransceiverCollector(logger *slog.Logger, metricFilter MetricFilter) *transceiverCollector {
+func NewTransceiverCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *transceiverCollector {
+	return newTransceiverCollector(logger, metricFilter, redisClient, loadTransceiverCollectorConfig(logger))
+}
+
+func newTransceiverCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config transceiverCollectorConfig) *transceiverCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "transceiver"
@@ -59,27 +89,27 @@ func NewTransceiverCollector(logger *slog.Logger, metricFilter MetricFilter) *tr
 
 	collector := &transceiverCollector{
 		moduleInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "module_info"),
-			"Transceiver module state metadata, value is always 1", []string{"device", "module_state", "module_fault_cause"}, nil),
+			"Transceiver module state metadata, value is always 1", []string{"device", "module_state", "module_fault_cause", "namespace"}, nil),
 		statusValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status_value"),
-			"Transceiver status values from STATE_DB TRANSCEIVER_STATUS", []string{"device", "field"}, nil),
+			"Transceiver status values from STATE_DB TRANSCEIVER_STATUS", []string{"device", "field", "namespace"}, nil),
 		statusFlagValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status_flag_value"),
-			"Transceiver status flag value from STATE_DB TRANSCEIVER_STATUS_FLAG", []string{"device", "flag"}, nil),
+			"Transceiver status flag value from STATE_DB TRANSCEIVER_STATUS_FLAG", []string{"device", "flag", "namespace"}, nil),
 		statusFlagChanges: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status_flag_changes_total"),
-			"Transceiver status flag change count", []string{"device", "flag"}, nil),
+			"Transceiver status flag change count", []string{"device", "flag", "namespace"}, nil),
 		statusFlagLastSet: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status_flag_last_set_timestamp_seconds"),
-			"Unix timestamp when a transceiver status flag was last set", []string{"device", "flag"}, nil),
+			"Unix timestamp when a transceiver status flag was last set", []string{"device", "flag", "namespace"}, nil),
 		statusFlagLastClear: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "status_flag_last_clear_timestamp_seconds"),
-			"Unix timestamp when a transceiver status flag was last cleared", []string{"device", "flag"}, nil),
+			"Unix timestamp when a transceiver status flag was last cleared", []string{"device", "flag", "namespace"}, nil),
 		domFlagValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dom_flag_value"),
-			"Transceiver DOM flag value from STATE_DB TRANSCEIVER_DOM_FLAG", []string{"device", "flag"}, nil),
+			"Transceiver DOM flag value from STATE_DB TRANSCEIVER_DOM_FLAG", []string{"device", "flag", "namespace"}, nil),
 		domFlagChanges: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dom_flag_changes_total"),
-			"Transceiver DOM flag change count", []string{"device", "flag"}, nil),
+			"Transceiver DOM flag change count", []string{"device", "flag", "namespace"}, nil),
 		domFlagLastSet: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dom_flag_last_set_timestamp_seconds"),
-			"Unix timestamp when a transceiver DOM flag was last set", []string{"device", "flag"}, nil),
+			"Unix timestamp when a transceiver DOM flag was last set", []string{"device", "flag", "namespace"}, nil),
 		domFlagLastClear: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dom_flag_last_clear_timestamp_seconds"),
-			"Unix timestamp when a transceiver DOM flag was last cleared", []string{"device", "flag"}, nil),
+			"Unix timestamp when a transceiver DOM flag was last cleared", []string{"device", "flag", "namespace"}, nil),
 		domThresholdValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dom_threshold_value"),
-			"Transceiver DOM threshold values", []string{"device", "threshold"}, nil),
+			"Transceiver DOM threshold values", []string{"device", "threshold", "namespace"}, nil),
 		entriesSkipped: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
 			"Number of transceiver entries skipped during latest refresh", nil, nil),
 		entriesTruncated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_truncated"),
@@ -87,18 +117,14 @@ func NewTransceiverCollector(logger *slog.Logger, metricFilter MetricFilter) *tr
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
 			"Time it took for exporter to refresh transceiver metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether transceiver collector succeeded", nil, nil),
+			"Whether transceiver collector succeeded", []string{"namespace"}, nil),
 		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
 			"Age of latest transceiver cache refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config: transceiverCollectorConfig{
-			enabled:         parseBoolEnv(logger, "TRANSCEIVER_ENABLED", true),
-			refreshInterval: parseDurationEnv(logger, "TRANSCEIVER_REFRESH_INTERVAL", 60*time.Second),
-			timeout:         parseDurationEnv(logger, "TRANSCEIVER_TIMEOUT", 2*time.Second),
-			maxPorts:        parseIntEnv(logger, "TRANSCEIVER_MAX_PORTS", 1024),
-			redisScanCount:  128,
-		},
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -133,28 +159,42 @@ func (collector *transceiverCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.cacheAge
 }
 
+func (collector *transceiverCollector) Name() string {
+	return "transceiver"
+}
+
+func (collector *transceiverCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *transceiverCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *transceiverCollector) Collect(ch chan<- prometheus.Metric) {
 	if !collector.config.enabled {
 		return
 	}
 
 	collector.mu.RLock()
-	cachedMetrics := append([]prometheus.Metric{}, collector.cachedMetrics...)
+	snapshots := collector.snapshots
 	lastScrapeDuration := collector.lastScrapeDuration
-	lastSuccess := collector.lastSuccess
-	lastSkippedEntries := collector.lastSkippedEntries
-	lastTruncated := collector.lastTruncated
-	lastRefreshTime := collector.lastRefreshTime
 	collector.mu.RUnlock()
 
-	for _, metric := range cachedMetrics {
-		ch <- metric
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled("sonic_transceiver_collector_success") {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	cacheAge := 0.0
-	if !lastRefreshTime.IsZero() {
-		cacheAge = time.Since(lastRefreshTime).Seconds()
-	}
+	lastSkippedEntries, lastTruncated, cacheAge := summarizeNamespaces(snapshots)
+
 	if collector.metricFilter.Enabled("sonic_transceiver_entries_skipped") {
 		ch <- prometheus.MustNewConstMetric(collector.entriesSkipped, prometheus.GaugeValue, lastSkippedEntries)
 	}
@@ -164,9 +204,6 @@ func (collector *transceiverCollector) Collect(ch chan<- prometheus.Metric) {
 	if collector.metricFilter.Enabled("sonic_transceiver_scrape_duration_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled("sonic_transceiver_collector_success") {
-		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
-	}
 	if collector.metricFilter.Enabled("sonic_transceiver_cache_age_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
@@ -175,40 +212,34 @@ func (collector *transceiverCollector) Collect(ch chan<- prometheus.Metric) {
 func (collector *transceiverCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
 func (collector *transceiverCollector) refreshMetrics() {
 	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
-	defer cancel()
-	metrics, skippedEntries, truncated, err := collector.scrapeMetrics(ctx)
+
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "transceiver", collector.redisClient, collector.config.timeout, previous, collector.scrapeMetrics)
 	scrapeDuration := time.Since(start).Seconds()
 
 	collector.mu.Lock()
 	defer collector.mu.Unlock()
+
 	collector.lastScrapeDuration = scrapeDuration
-	if err != nil {
-		collector.lastSuccess = 0
-		collector.logger.Error("Error refreshing transceiver metrics", "error", err)
-		return
-	}
-	collector.cachedMetrics = metrics
-	collector.lastSkippedEntries = float64(skippedEntries)
-	collector.lastTruncated = truncated
-	collector.lastSuccess = 1
-	collector.lastRefreshTime = time.Now()
+	collector.snapshots = snapshots
 }
 
-func (collector *transceiverCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, float64, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
-
+func (collector *transceiverCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
 	statusKeys, err := redisClient.ScanKeysFromDb(ctx, "STATE_DB", "TRANSCEIVER_STATUS|*", collector.config.redisScanCount)
 	if err != nil {
 		return nil, 0, 0, fmt.Errorf("failed to scan transceiver status keys: %w", err)
@@ -219,30 +250,47 @@ func (collector *transceiverCollector) scrapeMetrics(ctx context.Context) ([]pro
 	skippedEntries := 0
 	truncated := 0.0
 
-	for index, statusKey := range statusKeys {
-		if index >= collector.config.maxPorts {
-			truncated = 1
-			skippedEntries += len(statusKeys) - index
-			break
-		}
+	if len(statusKeys) > collector.config.maxPorts {
+		truncated = 1
+		skippedEntries += len(statusKeys) - collector.config.maxPorts
+		statusKeys = statusKeys[:collector.config.maxPorts]
+	}
 
+	devices := make([]string, 0, len(statusKeys))
+	for _, statusKey := range statusKeys {
 		device, err := parseKeySuffix(statusKey, "TRANSCEIVER_STATUS|")
 		if err != nil {
 			skippedEntries++
 			continue
 		}
 
-		statusData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", statusKey)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver status entry %s: %w", statusKey, err)
+		devices = append(devices, device)
+	}
+
+	// All tables of all ports are read in one pipelined batch.
+	tableKeys := make([]string, 0, len(devices)*len(transceiverTables))
+	for _, device := range devices {
+		for _, table := range transceiverTables {
+			tableKeys = append(tableKeys, table+"|"+device)
 		}
+	}
+
+	tableData, err := redisClient.HgetAllManyFromDb(ctx, "STATE_DB", tableKeys)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read transceiver entries: %w", err)
+	}
+
+	for index, device := range devices {
+		tables := tableData[index*len(transceiverTables) : (index+1)*len(transceiverTables)]
+
+		statusData := tables[transceiverStatusTable]
 		if len(statusData) == 0 {
 			skippedEntries++
 			continue
 		}
 
-		if collector.metricFilter.Enabled("sonic_transceiver_module_info") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.moduleInfo, prometheus.GaugeValue, 1, device, statusData["module_state"], statusData["module_fault_cause"]))
+		if collector.metricFilter.SeriesEnabled("sonic_transceiver_module_info", "device", device, "module_state", statusData["module_state"], "module_fault_cause", statusData["module_fault_cause"], "namespace", namespace) {
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.moduleInfo, prometheus.GaugeValue, 1, device, statusData["module_state"], statusData["module_fault_cause"], namespace))
 		}
 
 		statusFields := make([]string, 0, len(statusData))
@@ -255,67 +303,32 @@ func (collector *transceiverCollector) scrapeMetrics(ctx context.Context) ([]pro
 				continue
 			}
 
-			if value, ok := parseBoolish(statusData[field]); ok && collector.metricFilter.Enabled("sonic_transceiver_status_value") {
-				metrics = append(metrics, prometheus.MustNewConstMetric(collector.statusValue, prometheus.GaugeValue, value, device, field))
+			if value, ok := parseBoolish(statusData[field]); ok && collector.metricFilter.SeriesEnabled("sonic_transceiver_status_value", "device", device, "field", field, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(collector.statusValue, prometheus.GaugeValue, value, device, field, namespace))
 			}
 		}
 
-		domFlagData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_DOM_FLAG|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver DOM flag entry for %s: %w", device, err)
-		}
-		domFlagChangesData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_DOM_FLAG_CHANGE_COUNT|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver DOM flag change-count entry for %s: %w", device, err)
-		}
-		domFlagSetData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_DOM_FLAG_SET_TIME|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver DOM flag set-time entry for %s: %w", device, err)
-		}
-		domFlagClearData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_DOM_FLAG_CLEAR_TIME|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver DOM flag clear-time entry for %s: %w", device, err)
-		}
-		collector.appendTransceiverFlags(metrics, &metrics, collector.domFlagValue, collector.domFlagChanges, collector.domFlagLastSet, collector.domFlagLastClear, device, domFlagData,
-			domFlagChangesData,
-			domFlagSetData,
-			domFlagClearData,
-			collector.metricFilter.Enabled("sonic_transceiver_dom_flag_value"),
-			collector.metricFilter.Enabled("sonic_transceiver_dom_flag_changes_total"),
-			collector.metricFilter.Enabled("sonic_transceiver_dom_flag_last_set_timestamp_seconds"),
-			collector.metricFilter.Enabled("sonic_transceiver_dom_flag_last_clear_timestamp_seconds"),
+		collector.appendTransceiverFlags(metrics, &metrics, collector.domFlagValue, collector.domFlagChanges, collector.domFlagLastSet, collector.domFlagLastClear, device, namespace, tables[transceiverDomFlagTable],
+			tables[transceiverDomFlagChangeCountTable],
+			tables[transceiverDomFlagSetTimeTable],
+			tables[transceiverDomFlagClearTimeTable],
+			"sonic_transceiver_dom_flag_value",
+			"sonic_transceiver_dom_flag_changes_total",
+			"sonic_transceiver_dom_flag_last_set_timestamp_seconds",
+			"sonic_transceiver_dom_flag_last_clear_timestamp_seconds",
 		)
 
-		statusFlagData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_STATUS_FLAG|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver status flag entry for %s: %w", device, err)
-		}
-		statusFlagChangesData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_STATUS_FLAG_CHANGE_COUNT|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver status flag change-count entry for %s: %w", device, err)
-		}
-		statusFlagSetData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_STATUS_FLAG_SET_TIME|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver status flag set-time entry for %s: %w", device, err)
-		}
-		statusFlagClearData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_STATUS_FLAG_CLEAR_TIME|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver status flag clear-time entry for %s: %w", device, err)
-		}
-		collector.appendTransceiverFlags(metrics, &metrics, collector.statusFlagValue, collector.statusFlagChanges, collector.statusFlagLastSet, collector.statusFlagLastClear, device, statusFlagData,
-			statusFlagChangesData,
-			statusFlagSetData,
-			statusFlagClearData,
-			collector.metricFilter.Enabled("sonic_transceiver_status_flag_value"),
-			collector.metricFilter.Enabled("sonic_transceiver_status_flag_changes_total"),
-			collector.metricFilter.Enabled("sonic_transceiver_status_flag_last_set_timestamp_seconds"),
-			collector.metricFilter.Enabled("sonic_transceiver_status_flag_last_clear_timestamp_seconds"),
+		collector.appendTransceiverFlags(metrics, &metrics, collector.statusFlagValue, collector.statusFlagChanges, collector.statusFlagLastSet, collector.statusFlagLastClear, device, namespace, tables[transceiverStatusFlagTable],
+			tables[transceiverStatusFlagChangeCountTable],
+			tables[transceiverStatusFlagSetTimeTable],
+			tables[transceiverStatusFlagClearTimeTable],
+			"sonic_transceiver_status_flag_value",
+			"sonic_transceiver_status_flag_changes_total",
+			"sonic_transceiver_status_flag_last_set_timestamp_seconds",
+			"sonic_transceiver_status_flag_last_clear_timestamp_seconds",
 		)
 
-		thresholdData, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "TRANSCEIVER_DOM_THRESHOLD|"+device)
-		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to read transceiver threshold entry for %s: %w", device, err)
-		}
+		thresholdData := tables[transceiverDomThresholdTable]
 		thresholdFields := make([]string, 0, len(thresholdData))
 		for field := range thresholdData {
 			thresholdFields = append(thresholdFields, field)
@@ -325,8 +338,8 @@ func (collector *transceiverCollector) scrapeMetrics(ctx context.Context) ([]pro
 			if field == "last_update_time" {
 				continue
 			}
-			if value, ok := parseCounterLike(thresholdData[field]); ok && collector.metricFilter.Enabled("sonic_transceiver_dom_threshold_value") {
-				metrics = append(metrics, prometheus.MustNewConstMetric(collector.domThresholdValue, prometheus.GaugeValue, value, device, field))
+			if value, ok := parseCounterLike(thresholdData[field]); ok && collector.metricFilter.SeriesEnabled("sonic_transceiver_dom_threshold_value", "device", device, "threshold", field, "namespace", namespace) {
+				metrics = append(metrics, prometheus.MustNewConstMetric(collector.domThresholdValue, prometheus.GaugeValue, value, device, field, namespace))
 			}
 		}
 	}
@@ -334,7 +347,7 @@ func (collector *transceiverCollector) scrapeMetrics(ctx context.Context) ([]pro
 	return metrics, skippedEntries, truncated, nil
 }
 
-func (collector *transceiverCollector) appendTransceiverFlags(_ []prometheus.Metric, metrics *[]prometheus.Metric, valueDesc, changeDesc, setDesc, clearDesc *prometheus.Desc, device string, values, changes, setTimes, clearTimes map[string]string, emitValues, emitChanges, emitSet, emitClear bool) {
+func (collector *transceiverCollector) appendTransceiverFlags(_ []prometheus.Metric, metrics *[]prometheus.Metric, valueDesc, changeDesc, setDesc, clearDesc *prometheus.Desc, device, namespace string, values, changes, setTimes, clearTimes map[string]string, valueName, changeName, setName, clearName string) {
 	fields := make([]string, 0, len(values))
 	for field := range values {
 		fields = append(fields, field)
@@ -342,17 +355,27 @@ func (collector *transceiverCollector) appendTransceiverFlags(_ []prometheus.Met
 	sort.Strings(fields)
 
 	for _, field := range fields {
-		if value, ok := parseBoolish(values[field]); ok && emitValues {
-			*metrics = append(*metrics, prometheus.MustNewConstMetric(valueDesc, prometheus.GaugeValue, value, device, field))
+		if value, ok := parseBoolish(values[field]); ok && collector.metricFilter.SeriesEnabled(valueName, "device", device, "flag", field, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(valueDesc, prometheus.GaugeValue, value, device, field, namespace))
 		}
-		if value, ok := parseCounterLike(changes[field]); ok && emitChanges {
-			*metrics = append(*metrics, prometheus.MustNewConstMetric(changeDesc, prometheus.CounterValue, value, device, field))
+		if value, ok := parseCounterLike(changes[field]); ok && collector.metricFilter.SeriesEnabled(changeName, "device", device, "flag", field, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(changeDesc, prometheus.CounterValue, value, device, field, namespace))
 		}
-		if value, ok := parseEventTime(setTimes[field]); ok && emitSet {
-			*metrics = append(*metrics, prometheus.MustNewConstMetric(setDesc, prometheus.GaugeValue, value, device, field))
+		if value, ok := parseEventTime(setTimes[field]); ok && collector.metricFilter.SeriesEnabled(setName, "device", device, "flag", field, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(setDesc, prometheus.GaugeValue, value, device, field, namespace))
 		}
-		if value, ok := parseEventTime(clearTimes[field]); ok && emitClear {
-			*metrics = append(*metrics, prometheus.MustNewConstMetric(clearDesc, prometheus.GaugeValue, value, device, field))
+		if value, ok := parseEventTime(clearTimes[field]); ok && collector.metricFilter.SeriesEnabled(clearName, "device", device, "flag", field, "namespace", namespace) {
+			*metrics = append(*metrics, prometheus.MustNewConstMetric(clearDesc, prometheus.GaugeValue, value, device, field, namespace))
 		}
 	}
 }
+
+func loadTransceiverCollectorConfig(logger *slog.Logger) transceiverCollectorConfig {
+	return transceiverCollectorConfig{
+		enabled:         parseBoolEnv(logger, "TRANSCEIVER_ENABLED", true),
+		refreshInterval: parseDurationEnv(logger, "TRANSCEIVER_REFRESH_INTERVAL", 60*time.Second),
+		timeout:         parseDurationEnv(logger, "TRANSCEIVER_TIMEOUT", 2*time.Second),
+		maxPorts:        parseIntEnv(logger, "TRANSCEIVER_MAX_PORTS", 1024),
+		redisScanCount:  128,
+	}
+}
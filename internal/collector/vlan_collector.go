@@ -35,14 +35,13 @@ type vlanCollector struct {
 
 	logger       *slog.Logger
 	metricFilter MetricFilter
+	redisClient  redis.Client
 	config       vlanCollectorConfig
+	stop         chan struct{}
 
 	mu                 sync.RWMutex
-	cachedMetrics      []prometheus.Metric
-	lastSuccess        float64
+	snapshots          map[string]namespaceSnapshot
 	lastScrapeDuration float64
-	lastSkippedEntries float64
-	lastRefreshTime    time.Time
 }
 
 type vlanMemberEntry struct {
@@ -50,7 +49,11 @@ type vlanMemberEntry struct {
 	taggingMode string
 }
 
-func NewVlanCollector(logger *slog.Logger, metricFilter MetricFilter) *vlanCollector {
+func NewVlanCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client) *vlanCollector {
+	return newVlanCollector(logger, metricFilter, redisClient, loadVlanCollectorConfig(logger))
+}
+
+func newVlanCollector(logger *slog.Logger, metricFilter MetricFilter, redisClient redis.Client, config vlanCollectorConfig) *vlanCollector {
 	const (
 		namespace = "sonic"
 		subsystem = "vlan"
@@ -58,26 +61,28 @@ func NewVlanCollector(logger *slog.Logger, metricFilter MetricFilter) *vlanColle
 
 	collector := &vlanCollector{
 		vlanInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "info"),
-			"Non-numeric data about VLAN, value is always 1", []string{"vlan", "vlan_id"}, nil),
+			"Non-numeric data about VLAN, value is always 1", []string{"vlan", "vlan_id", "namespace"}, nil),
 		vlanAdminStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "admin_status"),
-			"Administrative state of VLAN (1=up, 0=down)", []string{"vlan"}, nil),
+			"Administrative state of VLAN (1=up, 0=down)", []string{"vlan", "namespace"}, nil),
 		vlanOperStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "oper_status"),
-			"Operational state of VLAN (1=up, 0=down)", []string{"vlan"}, nil),
+			"Operational state of VLAN (1=up, 0=down)", []string{"vlan", "namespace"}, nil),
 		vlanMembers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "members"),
-			"Number of VLAN members", []string{"vlan"}, nil),
+			"Number of VLAN members", []string{"vlan", "namespace"}, nil),
 		vlanMemberInfo: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "member_info"),
-			"Non-numeric data about VLAN member, value is always 1", []string{"vlan", "member", "tagging_mode"}, nil),
+			"Non-numeric data about VLAN member, value is always 1", []string{"vlan", "member", "tagging_mode", "namespace"}, nil),
 		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
 			"Time it took for exporter to refresh VLAN metrics", nil, nil),
 		scrapeCollectorSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "collector_success"),
-			"Whether VLAN collector succeeded", nil, nil),
+			"Whether VLAN collector succeeded", []string{"namespace"}, nil),
 		cacheAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cache_age_seconds"),
 			"Age of latest VLAN cache refresh", nil, nil),
 		skippedEntries: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "entries_skipped"),
 			"Number of VLAN entries skipped during latest refresh", nil, nil),
 		logger:       logger,
 		metricFilter: metricFilter,
-		config:       loadVlanCollectorConfig(logger),
+		redisClient:  redisClient,
+		config:       config,
+		stop:         make(chan struct{}),
 	}
 
 	if !collector.config.enabled {
@@ -95,6 +100,21 @@ func (collector *vlanCollector) IsEnabled() bool {
 	return collector.config.enabled
 }
 
+func (collector *vlanCollector) Name() string {
+	return "vlan"
+}
+
+func (collector *vlanCollector) Health() CollectorHealth {
+	collector.mu.RLock()
+	defer collector.mu.RUnlock()
+
+	return namespaceHealth(collector.snapshots, collector.config.refreshInterval)
+}
+
+func (collector *vlanCollector) Stop() {
+	close(collector.stop)
+}
+
 func (collector *vlanCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.vlanInfo
 	ch <- collector.vlanAdminStatus
@@ -113,28 +133,24 @@ func (collector *vlanCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	collector.mu.RLock()
-	cachedMetrics := append([]prometheus.Metric{}, collector.cachedMetrics...)
-	lastSkippedEntries := collector.lastSkippedEntries
+	snapshots := collector.snapshots
 	lastScrapeDuration := collector.lastScrapeDuration
-	lastSuccess := collector.lastSuccess
-	lastRefreshTime := collector.lastRefreshTime
 	collector.mu.RUnlock()
 
-	for _, metric := range cachedMetrics {
-		ch <- metric
+	for _, namespace := range sortedNamespaces(snapshots) {
+		for _, metric := range snapshots[namespace].metrics {
+			ch <- metric
+		}
+		if collector.metricFilter.Enabled("sonic_vlan_collector_success") {
+			ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, snapshots[namespace].success, namespace)
+		}
 	}
 
-	cacheAge := 0.0
-	if !lastRefreshTime.IsZero() {
-		cacheAge = time.Since(lastRefreshTime).Seconds()
-	}
+	lastSkippedEntries, _, cacheAge := summarizeNamespaces(snapshots)
 
 	if collector.metricFilter.Enabled("sonic_vlan_scrape_duration_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.scrapeDuration, prometheus.GaugeValue, lastScrapeDuration)
 	}
-	if collector.metricFilter.Enabled("sonic_vlan_collector_success") {
-		ch <- prometheus.MustNewConstMetric(collector.scrapeCollectorSuccess, prometheus.GaugeValue, lastSuccess)
-	}
 	if collector.metricFilter.Enabled("sonic_vlan_cache_age_seconds") {
 		ch <- prometheus.MustNewConstMetric(collector.cacheAge, prometheus.GaugeValue, cacheAge)
 	}
@@ -147,43 +163,37 @@ func (collector *vlanCollector) refreshLoop() {
 	ticker := time.NewTicker(collector.config.refreshInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		collector.refreshMetrics()
+	for {
+		select {
+		case <-ticker.C:
+			collector.refreshMetrics()
+		case <-collector.stop:
+			return
+		}
 	}
 }
 
 func (collector *vlanCollector) refreshMetrics() {
 	start := time.Now()
-	ctx, cancel := context.WithTimeout(context.Background(), collector.config.timeout)
-	defer cancel()
 
-	metrics, skippedEntries, err := collector.scrapeMetrics(ctx)
+	collector.mu.RLock()
+	previous := collector.snapshots
+	collector.mu.RUnlock()
+
+	snapshots := refreshNamespaces(collector.logger, "VLAN", collector.redisClient, collector.config.timeout, previous, func(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, float64, error) {
+		metrics, skippedEntries, err := collector.scrapeMetrics(ctx, redisClient, namespace)
+		return metrics, skippedEntries, 0, err
+	})
 	scrapeDuration := time.Since(start).Seconds()
 
 	collector.mu.Lock()
 	defer collector.mu.Unlock()
 
 	collector.lastScrapeDuration = scrapeDuration
-
-	if err != nil {
-		collector.lastSuccess = 0
-		collector.logger.Error("Error refreshing VLAN metrics", "error", err)
-		return
-	}
-
-	collector.cachedMetrics = metrics
-	collector.lastSkippedEntries = float64(skippedEntries)
-	collector.lastSuccess = 1
-	collector.lastRefreshTime = time.Now()
+	collector.snapshots = snapshots
 }
 
-func (collector *vlanCollector) scrapeMetrics(ctx context.Context) ([]prometheus.Metric, int, error) {
-	redisClient, err := redis.NewClient()
-	if err != nil {
-		return nil, 0, fmt.Errorf("redis client initialization failed: %w", err)
-	}
-	defer redisClient.Close()
-
+func (collector *vlanCollector) scrapeMetrics(ctx context.Context, redisClient redis.Client, namespace string) ([]prometheus.Metric, int, error) {
 	configVlans, err := redisClient.ScanKeysFromDb(ctx, "CONFIG_DB", "VLAN|*", collector.config.redisScanCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to scan VLAN keys: %w", err)
@@ -271,15 +281,15 @@ func (collector *vlanCollector) scrapeMetrics(ctx context.Context) ([]prometheus
 
 		vlanID := firstNonEmpty(configData["vlanid"], strings.TrimPrefix(vlanName, "Vlan"))
 		if collector.metricFilter.Enabled("sonic_vlan_info") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanInfo, prometheus.GaugeValue, 1, vlanName, vlanID))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanInfo, prometheus.GaugeValue, 1, vlanName, vlanID, namespace))
 		}
 
 		if adminStatus := firstNonEmpty(applData["admin_status"], configData["admin_status"]); adminStatus != "" && collector.metricFilter.Enabled("sonic_vlan_admin_status") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanAdminStatus, prometheus.GaugeValue, statusToGauge(adminStatus), vlanName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanAdminStatus, prometheus.GaugeValue, statusToGauge(adminStatus), vlanName, namespace))
 		}
 
 		if operStatus := applData["oper_status"]; operStatus != "" && collector.metricFilter.Enabled("sonic_vlan_oper_status") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanOperStatus, prometheus.GaugeValue, statusToGauge(operStatus), vlanName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanOperStatus, prometheus.GaugeValue, statusToGauge(operStatus), vlanName, namespace))
 		}
 
 		members := membersByVlan[vlanName]
@@ -302,6 +312,7 @@ func (collector *vlanCollector) scrapeMetrics(ctx context.Context) ([]prometheus
 					vlanName,
 					member.name,
 					member.taggingMode,
+					namespace,
 				))
 			}
 
@@ -310,7 +321,7 @@ func (collector *vlanCollector) scrapeMetrics(ctx context.Context) ([]prometheus
 		}
 
 		if collector.metricFilter.Enabled("sonic_vlan_members") {
-			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanMembers, prometheus.GaugeValue, float64(memberCount), vlanName))
+			metrics = append(metrics, prometheus.MustNewConstMetric(collector.vlanMembers, prometheus.GaugeValue, float64(memberCount), vlanName, namespace))
 		}
 		processedVlans++
 	}
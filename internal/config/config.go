@@ -0,0 +1,434 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type settingKind int
+
+const (
+	kindString settingKind = iota
+	kindBool
+	// kindDuration must be greater than zero
+	kindDuration
+	// kindInt must be greater than zero
+	kindInt
+	kindNonNegativeInt
+	// kindMetricPatterns is a comma separated list of names and glob patterns
+	kindMetricPatterns
+)
+
+// settings lists every env var understood by the exporter and its kind.
+var settings = map[string]settingKind{
+	"REDIS_ADDRESS":              kindString,
+	"REDIS_PASSWORD":             kindString,
+	"REDIS_NETWORK":              kindString,
+	"REDIS_POOL_SIZE":            kindInt,
+	"REDIS_MIN_IDLE_CONNS":       kindNonNegativeInt,
+	"REDIS_CONN_MAX_IDLE_TIME":   kindDuration,
+	"REDIS_PIPELINE_BATCH_SIZE":  kindInt,
+	"REDIS_DATABASE_CONFIG_FILE": kindString,
+	"REDIS_DATABASE_GLOBAL_FILE": kindString,
+	"REDIS_MAX_NAMESPACES":       kindInt,
+
+	"SONIC_DISABLED_METRICS":   kindMetricPatterns,
+	"SONIC_ENABLED_METRICS":    kindMetricPatterns,
+	"SONIC_METRIC_LABEL_RULES": kindString,
+
+	"REDIS_POOL_ENABLED": kindBool,
+
+	"INTERFACE_ENABLED":          kindBool,
+	"INTERFACE_REFRESH_INTERVAL": kindDuration,
+	"INTERFACE_TIMEOUT":          kindDuration,
+	"INTERFACE_SAI_STATS":        kindMetricPatterns,
+	"INTERFACE_MAX_SAI_STATS":    kindInt,
+	"INTERFACE_PFC_ENABLED":      kindBool,
+
+	"HW_ENABLED":          kindBool,
+	"HW_REFRESH_INTERVAL": kindDuration,
+	"HW_TIMEOUT":          kindDuration,
+
+	"CRM_ENABLED":          kindBool,
+	"CRM_REFRESH_INTERVAL": kindDuration,
+	"CRM_TIMEOUT":          kindDuration,
+
+	"QUEUE_ENABLED":                  kindBool,
+	"QUEUE_REFRESH_INTERVAL":         kindDuration,
+	"QUEUE_TIMEOUT":                  kindDuration,
+	"QUEUE_LEGACY_WATERMARK_METRICS": kindBool,
+
+	"PRIORITY_GROUP_ENABLED":          kindBool,
+	"PRIORITY_GROUP_REFRESH_INTERVAL": kindDuration,
+	"PRIORITY_GROUP_TIMEOUT":          kindDuration,
+
+	"PFCWD_ENABLED":          kindBool,
+	"PFCWD_REFRESH_INTERVAL": kindDuration,
+	"PFCWD_TIMEOUT":          kindDuration,
+
+	"BUFFER_POOL_ENABLED":          kindBool,
+	"BUFFER_POOL_REFRESH_INTERVAL": kindDuration,
+	"BUFFER_POOL_TIMEOUT":          kindDuration,
+
+	"RIF_ENABLED":          kindBool,
+	"RIF_REFRESH_INTERVAL": kindDuration,
+	"RIF_TIMEOUT":          kindDuration,
+	"RIF_MAX_INTERFACES":   kindInt,
+
+	"NODE_COLLECTORS": kindString,
+
+	"LLDP_ENABLED":          kindBool,
+	"LLDP_INCLUDE_MGMT":     kindBool,
+	"LLDP_REFRESH_INTERVAL": kindDuration,
+	"LLDP_TIMEOUT":          kindDuration,
+	"LLDP_MAX_NEIGHBORS":    kindInt,
+
+	"VLAN_ENABLED":          kindBool,
+	"VLAN_REFRESH_INTERVAL": kindDuration,
+	"VLAN_TIMEOUT":          kindDuration,
+	"VLAN_MAX_VLANS":        kindInt,
+	"VLAN_MAX_MEMBERS":      kindInt,
+
+	"LAG_ENABLED":          kindBool,
+	"LAG_REFRESH_INTERVAL": kindDuration,
+	"LAG_TIMEOUT":          kindDuration,
+	"LAG_MAX_LAGS":         kindInt,
+	"LAG_MAX_MEMBERS":      kindInt,
+
+	"FDB_ENABLED":          kindBool,
+	"FDB_REFRESH_INTERVAL": kindDuration,
+	"FDB_TIMEOUT":          kindDuration,
+	"FDB_MAX_ENTRIES":      kindInt,
+	"FDB_MAX_PORTS":        kindInt,
+	"FDB_MAX_VLANS":        kindInt,
+
+	"ROUTING_ENABLED":          kindBool,
+	"ROUTING_REFRESH_INTERVAL": kindDuration,
+	"ROUTING_TIMEOUT":          kindDuration,
+	"ROUTING_MAX_ROUTES":       kindInt,
+	"ROUTING_MAX_NEIGHBORS":    kindInt,
+
+	"SWITCH_ENABLED":          kindBool,
+	"SWITCH_REFRESH_INTERVAL": kindDuration,
+	"SWITCH_TIMEOUT":          kindDuration,
+	"SWITCH_MAX_ENTRIES":      kindInt,
+
+	"THERMAL_ENABLED":          kindBool,
+	"THERMAL_REFRESH_INTERVAL": kindDuration,
+	"THERMAL_TIMEOUT":          kindDuration,
+
+	"TRANSCEIVER_ENABLED":          kindBool,
+	"TRANSCEIVER_REFRESH_INTERVAL": kindDuration,
+	"TRANSCEIVER_TIMEOUT":          kindDuration,
+	"TRANSCEIVER_MAX_PORTS":        kindInt,
+
+	"PLATFORM_HEALTH_ENABLED":             kindBool,
+	"PLATFORM_HEALTH_REFRESH_INTERVAL":    kindDuration,
+	"PLATFORM_HEALTH_TIMEOUT":             kindDuration,
+	"PLATFORM_HEALTH_MAX_PROCESSES":       kindInt,
+	"PLATFORM_HEALTH_MAX_STORAGE_DEVICES": kindInt,
+
+	"SYSTEM_ENABLED":                  kindBool,
+	"SYSTEM_REFRESH_INTERVAL":         kindDuration,
+	"SYSTEM_TIMEOUT":                  kindDuration,
+	"SYSTEM_COMMAND_ENABLED":          kindBool,
+	"SYSTEM_COMMAND_TIMEOUT":          kindDuration,
+	"SYSTEM_COMMAND_MAX_OUTPUT_BYTES": kindInt,
+	"SYSTEM_HOSTNAME_FILE":            kindString,
+	"SYSTEM_MACHINE_CONF_FILE":        kindString,
+	"SYSTEM_UPTIME_FILE":              kindString,
+	"SYSTEM_VERSION_FILE":             kindString,
+
+	"DOCKER_ENABLED":                kindBool,
+	"DOCKER_REFRESH_INTERVAL":       kindDuration,
+	"DOCKER_TIMEOUT":                kindDuration,
+	"DOCKER_MAX_CONTAINERS":         kindInt,
+	"DOCKER_SOURCE_STALE_THRESHOLD": kindDuration,
+
+	"FRR_ENABLED":                                kindBool,
+	"FRR_SOCKET_DIR_PATH":                        kindString,
+	"FRR_SOCKET_TIMEOUT":                         kindDuration,
+	"FRR_VTYSH_ENABLED":                          kindBool,
+	"FRR_VTYSH_PATH":                             kindString,
+	"FRR_VTYSH_OPTIONS":                          kindString,
+	"FRR_VTYSH_TIMEOUT":                          kindDuration,
+	"FRR_VTYSH_SUDO":                             kindBool,
+	"FRR_BGP_ENABLED":                            kindBool,
+	"FRR_BGP6_ENABLED":                           kindBool,
+	"FRR_BGPL2VPN_ENABLED":                       kindBool,
+	"FRR_OSPF_ENABLED":                           kindBool,
+	"FRR_PIM_ENABLED":                            kindBool,
+	"FRR_BFD_ENABLED":                            kindBool,
+	"FRR_ROUTE_ENABLED":                          kindBool,
+	"FRR_ROUTE_DETAILED_ENABLED":                 kindBool,
+	"FRR_STATUS_ENABLED":                         kindBool,
+	"FRR_VRRP_ENABLED":                           kindBool,
+	"FRR_RPKI_ENABLED":                           kindBool,
+	"FRR_OSPF_INSTANCES":                         kindString,
+	"FRR_BGP_PEER_TYPES_ENABLED":                 kindBool,
+	"FRR_BGP_PEER_TYPES_KEYS":                    kindString,
+	"FRR_BGP_PEER_DESCRIPTIONS_ENABLED":          kindBool,
+	"FRR_BGP_PEER_DESCRIPTIONS_PLAIN_TEXT":       kindBool,
+	"FRR_BGP_PEER_HOSTNAMES_ENABLED":             kindBool,
+	"FRR_BGP_PEER_GROUPS_ENABLED":                kindBool,
+	"FRR_BGP_NEXT_HOP_INTERFACE_ENABLED":         kindBool,
+	"FRR_BGP_ADVERTISED_PREFIXES_ENABLED":        kindBool,
+	"FRR_BGP_ACCEPTED_FILTERED_PREFIXES_ENABLED": kindBool,
+	"FRR_BGP_MONITORED_PREFIXES_FILE":            kindString,
+}
+
+var (
+	mu sync.Mutex
+	// fileKeys are env vars exported from the config file by the latest Load.
+	// They are not treated as env overrides when the file is loaded again.
+	fileKeys = map[string]struct{}{}
+)
+
+// Layout of the YAML configuration file. Every key maps onto the env var of
+// the same setting: redis.pool_size is REDIS_POOL_SIZE and
+// collectors.lldp.refresh_interval is LLDP_REFRESH_INTERVAL.
+type file struct {
+	Redis           map[string]yaml.Node            `yaml:"redis"`
+	DisabledMetrics []string                        `yaml:"disabled_metrics"`
+	EnabledMetrics  []string                        `yaml:"enabled_metrics"`
+	LabelRules      []string                        `yaml:"label_rules"`
+	Collectors      map[string]map[string]yaml.Node `yaml:"collectors"`
+}
+
+// setting is a single value read from the configuration file.
+type setting struct {
+	path  string
+	value string
+}
+
+// Load reads the YAML configuration file at path and validates every setting
+// of the file and the environment. An empty path reads the environment only.
+//
+// Env vars override the file. File values are exported to the process
+// environment when the env var is not set, so collectors and the Redis client
+// keep reading their settings through env. Any invalid value fails with an
+// error naming the setting and its source, the environment is left untouched
+// in that case. Load may be called again to reload the file.
+func Load(path string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	fileSettings := map[string]setting{}
+	if path != "" {
+		var err error
+		fileSettings, err = readFile(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, key := range sortedSettings() {
+		value, source := lookup(key, fileSettings)
+		if value == "" {
+			continue
+		}
+
+		if err := validate(settings[key], value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q from %s: %w", key, value, source, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for key := range fileKeys {
+		if err := os.Unsetenv(key); err != nil {
+			return fmt.Errorf("failed to reset %s: %w", key, err)
+		}
+	}
+
+	appliedKeys := map[string]struct{}{}
+	for key, fileSetting := range fileSettings {
+		if _, exists := lookupEnv(key); exists {
+			continue
+		}
+
+		if err := os.Setenv(key, fileSetting.value); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", fileSetting.path, err)
+		}
+		appliedKeys[key] = struct{}{}
+	}
+	fileKeys = appliedKeys
+
+	return nil
+}
+
+func readFile(path string) (map[string]setting, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config file
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	fileSettings := map[string]setting{}
+	var errs []error
+
+	add := func(settingPath, key string, node yaml.Node) {
+		if _, ok := settings[key]; !ok {
+			errs = append(errs, fmt.Errorf("unknown setting %s in %s", settingPath, path))
+			return
+		}
+
+		value, err := scalarValue(node)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s in %s: %w", settingPath, path, err))
+			return
+		}
+
+		fileSettings[key] = setting{path: settingPath, value: value}
+	}
+
+	for name, node := range config.Redis {
+		add("redis."+name, "REDIS_"+strings.ToUpper(name), node)
+	}
+	for collector, values := range config.Collectors {
+		for name, node := range values {
+			add("collectors."+collector+"."+name, strings.ToUpper(collector+"_"+name), node)
+		}
+	}
+	if len(config.DisabledMetrics) > 0 {
+		fileSettings["SONIC_DISABLED_METRICS"] = setting{
+			path:  "disabled_metrics",
+			value: strings.Join(config.DisabledMetrics, ","),
+		}
+	}
+	if len(config.EnabledMetrics) > 0 {
+		fileSettings["SONIC_ENABLED_METRICS"] = setting{
+			path:  "enabled_metrics",
+			value: strings.Join(config.EnabledMetrics, ","),
+		}
+	}
+	if len(config.LabelRules) > 0 {
+		// Rules may contain commas in regexps, so they are separated by semicolons
+		fileSettings["SONIC_METRIC_LABEL_RULES"] = setting{
+			path:  "label_rules",
+			value: strings.Join(config.LabelRules, ";"),
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return fileSettings, nil
+}
+
+// scalarValue returns a YAML scalar as it would be written in env. Lists are
+// joined with commas.
+func scalarValue(node yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", errors.New("list items must be scalars")
+			}
+			values = append(values, item.Value)
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	return "", fmt.Errorf("line %d: must be a scalar or a list", node.Line)
+}
+
+func lookup(key string, fileSettings map[string]setting) (string, string) {
+	if value, exists := lookupEnv(key); exists {
+		return value, "env"
+	}
+
+	if fileSetting, ok := fileSettings[key]; ok {
+		return fileSetting.value, fileSetting.path
+	}
+
+	return "", ""
+}
+
+// lookupEnv returns a non-empty env var that was not exported from the
+// config file.
+func lookupEnv(key string) (string, bool) {
+	if _, ok := fileKeys[key]; ok {
+		return "", false
+	}
+
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+func validate(kind settingKind, value string) error {
+	switch kind {
+	case kindBool:
+		_, err := strconv.ParseBool(value)
+		return err
+	case kindDuration:
+		parsedValue, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		if parsedValue <= 0 {
+			return errors.New("must be greater than zero")
+		}
+	case kindInt:
+		parsedValue, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if parsedValue <= 0 {
+			return errors.New("must be greater than zero")
+		}
+	case kindNonNegativeInt:
+		parsedValue, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if parsedValue < 0 {
+			return errors.New("must not be negative")
+		}
+	case kindMetricPatterns:
+		for _, pattern := range strings.Split(value, ",") {
+			if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", strings.TrimSpace(pattern), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortedSettings() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sonic-exporter.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadAppliesFileAndKeepsEnvOverrides(t *testing.T) {
+	// Registers cleanup for values exported by Load
+	for _, key := range []string{"REDIS_POOL_SIZE", "LLDP_REFRESH_INTERVAL", "FDB_ENABLED", "SONIC_DISABLED_METRICS"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("LLDP_MAX_NEIGHBORS", "64")
+
+	path := writeConfigFile(t, `
+redis:
+  pool_size: 4
+disabled_metrics:
+  - sonic_queue_*
+  - sonic_interface_mtu_bytes
+collectors:
+  lldp:
+    refresh_interval: 10s
+    max_neighbors: 128
+  fdb:
+    enabled: true
+`)
+
+	if err := Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"REDIS_POOL_SIZE":        "4",
+		"LLDP_REFRESH_INTERVAL":  "10s",
+		"LLDP_MAX_NEIGHBORS":     "64",
+		"FDB_ENABLED":            "true",
+		"SONIC_DISABLED_METRICS": "sonic_queue_*,sonic_interface_mtu_bytes",
+	}
+	for key, value := range expected {
+		if got := os.Getenv(key); got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestLoadRejectsInvalidValues(t *testing.T) {
+	t.Setenv("VLAN_TIMEOUT", "soon")
+	t.Setenv("INTERFACE_SAI_STATS", "SAI_PORT_STAT_WRED_*, SAI_PORT_STAT_[")
+
+	path := writeConfigFile(t, `
+collectors:
+  lldp:
+    refresh_interval: -5s
+  fdb:
+    max_entries: many
+    enabled: maybe
+`)
+
+	err := Load(path)
+	if err == nil {
+		t.Fatal("expected Load to fail")
+	}
+
+	for _, message := range []string{
+		`invalid FDB_ENABLED "maybe" from collectors.fdb.enabled`,
+		`invalid FDB_MAX_ENTRIES "many" from collectors.fdb.max_entries`,
+		`invalid LLDP_REFRESH_INTERVAL "-5s" from collectors.lldp.refresh_interval: must be greater than zero`,
+		`invalid VLAN_TIMEOUT "soon" from env`,
+		`invalid INTERFACE_SAI_STATS "SAI_PORT_STAT_WRED_*, SAI_PORT_STAT_[" from env: invalid pattern "SAI_PORT_STAT_["`,
+	} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error to contain %q, got: %v", message, err)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownSettings(t *testing.T) {
+	for _, testCase := range []struct {
+		name    string
+		content string
+		message string
+	}{
+		{
+			name:    "collector key",
+			content: "collectors:\n  lldp:\n    refresh_intervall: 10s\n",
+			message: "unknown setting collectors.lldp.refresh_intervall",
+		},
+		{
+			name:    "top level key",
+			content: "lldp:\n  enabled: true\n",
+			message: "field lldp not found",
+		},
+		{
+			name:    "nested value",
+			content: "redis:\n  address:\n    host: localhost\n",
+			message: "invalid redis.address",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Load(writeConfigFile(t, testCase.content))
+			if err == nil || !strings.Contains(err.Error(), testCase.message) {
+				t.Fatalf("expected error containing %q, got: %v", testCase.message, err)
+			}
+		})
+	}
+}
+
+func TestLoadFailsOnMissingFile(t *testing.T) {
+	if err := Load(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Fatal("expected Load to fail for a missing file")
+	}
+}
+
+func TestLoadAgainReplacesFileValues(t *testing.T) {
+	t.Setenv("LAG_MAX_LAGS", "")
+	t.Setenv("LAG_TIMEOUT", "")
+
+	path := writeConfigFile(t, "collectors:\n  lag:\n    max_lags: 16\n    timeout: 1s\n")
+	if err := Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("collectors:\n  lag:\n    max_lags: 32\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite config file: %v", err)
+	}
+	if err := Load(path); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+
+	if got := os.Getenv("LAG_MAX_LAGS"); got != "32" {
+		t.Errorf("expected LAG_MAX_LAGS=32 after reload, got %q", got)
+	}
+	if got, exists := os.LookupEnv("LAG_TIMEOUT"); exists {
+		t.Errorf("expected LAG_TIMEOUT removed from file to be unset, got %q", got)
+	}
+}
@@ -3,16 +3,53 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/redis/go-redis/v9"
 )
 
+// DefaultNamespace is the host namespace. It is the only namespace on
+// single-ASIC platforms.
+const DefaultNamespace = ""
+
+// Client is bound to one namespace, use Namespace to address the others.
 type Client struct {
-	databases map[string]*redis.Client
-	config    RedisConfig
+	namespace  string
+	databases  map[string]*redis.Client
+	namespaces map[string]map[string]*redis.Client
+	config     RedisConfig
+}
+
+// PoolStats is a snapshot of the connection pool of a single database client.
+type PoolStats struct {
+	Namespace  string
+	Database   string
+	Hits       uint32
+	Misses     uint32
+	Timeouts   uint32
+	WaitCount  uint32
+	TotalConns uint32
+	IdleConns  uint32
+	StaleConns uint32
 }
 
+var fallbackDatabaseNames = []string{
+	"APPL_DB",
+	"ASIC_DB",
+	"COUNTERS_DB",
+	"LOGLEVEL_DB",
+	"CONFIG_DB",
+	"FLEX_COUNTER_DB",
+	"STATE_DB",
+	"CHASSIS_APP_DB",
+	"CHASSIS_STATE_DB",
+}
+
+// RedisDbId returns the well-known SONiC database id used when
+// database_config.json is not available.
 func RedisDbId(name string) (int, bool) {
 	switch name {
 	case "APPL_DB":
@@ -21,21 +58,43 @@ func RedisDbId(name string) (int, bool) {
 		return 2, true
 	case "ASIC_DB":
 		return 1, true
+	case "LOGLEVEL_DB":
+		return 3, true
 	case "CONFIG_DB":
 		return 4, true
+	case "FLEX_COUNTER_DB":
+		return 5, true
 	case "STATE_DB":
 		return 6, true
+	case "CHASSIS_APP_DB":
+		return 12, true
+	case "CHASSIS_STATE_DB":
+		return 13, true
 	}
 
 	return 0, false
 }
 
 type RedisConfig struct {
-	Address  string `env:"REDIS_ADDRESS" env-default:"localhost:6379"`
-	Password string `env:"REDIS_PASSWORD" env-default:""`
-	Network  string `env:"REDIS_NETWORK" env-default:"tcp"`
+	Address           string        `env:"REDIS_ADDRESS" env-default:"localhost:6379"`
+	Password          string        `env:"REDIS_PASSWORD" env-default:""`
+	Network           string        `env:"REDIS_NETWORK" env-default:"tcp"`
+	PoolSize          int           `env:"REDIS_POOL_SIZE" env-default:"8"`
+	MinIdleConns      int           `env:"REDIS_MIN_IDLE_CONNS" env-default:"0"`
+	ConnMaxIdleTime   time.Duration `env:"REDIS_CONN_MAX_IDLE_TIME" env-default:"5m"`
+	PipelineBatchSize int           `env:"REDIS_PIPELINE_BATCH_SIZE" env-default:"512"`
+
+	DatabaseConfigFile string `env:"REDIS_DATABASE_CONFIG_FILE" env-default:"/var/run/redis/sonic-db/database_config.json"`
+	DatabaseGlobalFile string `env:"REDIS_DATABASE_GLOBAL_FILE" env-default:"/var/run/redis/sonic-db/database_global.json"`
+	MaxNamespaces      int    `env:"REDIS_MAX_NAMESPACES" env-default:"16"`
 }
 
+// NewClient reads redis config from env, resolves SONiC databases of every
+// namespace and creates one pooled client per namespace and database. The
+// returned Client is bound to DefaultNamespace.
+// Connections are dialed lazily by the pool, so the returned Client is safe
+// to share between goroutines and should be created once per process and
+// closed on shutdown.
 func NewClient() (Client, error) {
 	var cfg RedisConfig
 	c := Client{}
@@ -45,48 +104,135 @@ func NewClient() (Client, error) {
 		return c, errors.New("failed to read redis config")
 	}
 
+	if cfg.PoolSize <= 0 {
+		return c, fmt.Errorf("invalid REDIS_POOL_SIZE %d: must be greater than zero", cfg.PoolSize)
+	}
+	if cfg.MinIdleConns < 0 {
+		return c, fmt.Errorf("invalid REDIS_MIN_IDLE_CONNS %d: must not be negative", cfg.MinIdleConns)
+	}
+	if cfg.PipelineBatchSize <= 0 {
+		return c, fmt.Errorf("invalid REDIS_PIPELINE_BATCH_SIZE %d: must be greater than zero", cfg.PipelineBatchSize)
+	}
+	if cfg.MaxNamespaces <= 0 {
+		return c, fmt.Errorf("invalid REDIS_MAX_NAMESPACES %d: must be greater than zero", cfg.MaxNamespaces)
+	}
+
+	namespaces, err := resolveNamespaces(cfg)
+	if err != nil {
+		return c, err
+	}
+
 	c.config = cfg
-	c.databases = make(map[string]*redis.Client)
+	c.namespaces = make(map[string]map[string]*redis.Client, len(namespaces))
+
+	for namespace, databases := range namespaces {
+		c.namespaces[namespace] = make(map[string]*redis.Client, len(databases))
+
+		for dbName, endpoint := range databases {
+			c.namespaces[namespace][dbName] = redis.NewClient(&redis.Options{
+				Network:         endpoint.network,
+				Addr:            endpoint.address,
+				Password:        c.config.Password,
+				DB:              endpoint.dbId,
+				PoolSize:        c.config.PoolSize,
+				MinIdleConns:    c.config.MinIdleConns,
+				ConnMaxIdleTime: c.config.ConnMaxIdleTime,
+			})
+		}
+	}
 
-	return c, nil
+	return c.Namespace(DefaultNamespace), nil
 }
 
-func (c *Client) connect(dbName string) error {
-	dbId, ok := RedisDbId(dbName)
-	if ok {
-		c.databases[dbName] = redis.NewClient(&redis.Options{
-			Network:  c.config.Network,
-			Addr:     c.config.Address,
-			Password: c.config.Password,
-			DB:       dbId,
-		})
-		return nil
+// Namespaces returns all discovered namespaces in sorted order.
+func (c Client) Namespaces() []string {
+	namespaces := make([]string, 0, len(c.namespaces))
+	for namespace := range c.namespaces {
+		namespaces = append(namespaces, namespace)
 	}
+	sort.Strings(namespaces)
 
-	return errors.New("database not defined")
+	return namespaces
 }
 
-func (c *Client) selectClient(dbName string) (*redis.Client, error) {
-	var client *redis.Client
+// Namespace returns a client sharing the same pools, bound to the given
+// namespace. Reads through a client bound to an unknown namespace fail.
+func (c Client) Namespace(namespace string) Client {
+	c.namespace = namespace
+	c.databases = c.namespaces[namespace]
 
-	_, ok := RedisDbId(dbName)
+	return c
+}
 
-	if ok {
-		client, ok = c.databases[dbName]
+func (c Client) selectClient(dbName string) (*redis.Client, error) {
+	client, ok := c.databases[dbName]
+	if !ok {
+		return nil, fmt.Errorf("database %s not defined in namespace %q", dbName, c.namespace)
+	}
 
-		if !ok {
-			err := c.connect(dbName)
-			if err != nil {
-				return nil, err
-			}
+	return client, nil
+}
+
+// PoolStats returns connection pool statistics of every namespace and
+// database, sorted by namespace and database name.
+func (c Client) PoolStats() []PoolStats {
+	var stats []PoolStats
+	for _, namespace := range c.Namespaces() {
+		databases := c.namespaces[namespace]
 
-			client = c.databases[dbName]
+		dbNames := make([]string, 0, len(databases))
+		for dbName := range databases {
+			dbNames = append(dbNames, dbName)
+		}
+		sort.Strings(dbNames)
+
+		for _, dbName := range dbNames {
+			poolStats := databases[dbName].PoolStats()
+			stats = append(stats, PoolStats{
+				Namespace:  namespace,
+				Database:   dbName,
+				Hits:       poolStats.Hits,
+				Misses:     poolStats.Misses,
+				Timeouts:   poolStats.Timeouts,
+				WaitCount:  poolStats.WaitCount,
+				TotalConns: poolStats.TotalConns,
+				IdleConns:  poolStats.IdleConns,
+				StaleConns: poolStats.StaleConns,
+			})
 		}
+	}
+
+	return stats
+}
 
-		return client, nil
+// Ping checks that the Redis instances serving dbNames in every namespace are
+// reachable. Each instance is pinged once, however many of dbNames it serves.
+// Databases a namespace does not define are skipped.
+func (c Client) Ping(ctx context.Context, dbNames ...string) error {
+	var errs []error
+	pinged := make(map[string]bool)
+	for _, namespace := range c.Namespaces() {
+		databases := c.namespaces[namespace]
+
+		for _, dbName := range dbNames {
+			client, ok := databases[dbName]
+			if !ok {
+				continue
+			}
+
+			instance := client.Options().Network + "/" + client.Options().Addr
+			if pinged[instance] {
+				continue
+			}
+			pinged[instance] = true
+
+			if err := client.Ping(ctx).Err(); err != nil {
+				errs = append(errs, fmt.Errorf("database %s in namespace %q: %w", dbName, namespace, err))
+			}
+		}
 	}
 
-	return nil, errors.New("database not defined")
+	return errors.Join(errs...)
 }
 
 // Issue a HGETALL on key in a selected database
@@ -100,6 +246,38 @@ func (c Client) HgetAllFromDb(ctx context.Context, dbName, key string) (map[stri
 	return data, err
 }
 
+// HgetAllManyFromDb issues HGETALL for every key in a selected database.
+// Commands are pipelined in batches of REDIS_PIPELINE_BATCH_SIZE, so reading
+// many keys costs one round trip per batch instead of one per key. Results
+// are in the order of keys, a missing key yields an empty map.
+func (c Client) HgetAllManyFromDb(ctx context.Context, dbName string, keys []string) ([]map[string]string, error) {
+	client, err := c.selectClient(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]string, 0, len(keys))
+	for start := 0; start < len(keys); start += c.config.PipelineBatchSize {
+		batch := keys[start:min(start+c.config.PipelineBatchSize, len(keys))]
+
+		pipe := client.Pipeline()
+		cmds := make([]*redis.MapStringStringCmd, 0, len(batch))
+		for _, key := range batch {
+			cmds = append(cmds, pipe.HGetAll(ctx, key))
+		}
+
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		for _, cmd := range cmds {
+			results = append(results, cmd.Val())
+		}
+	}
+
+	return results, nil
+}
+
 func (c Client) HsetToDb(ctx context.Context, dbName, key string, data map[string]string) error {
 	client, err := c.selectClient(dbName)
 	if err != nil {
@@ -150,9 +328,14 @@ func (c Client) ScanKeysFromDb(ctx context.Context, dbName, pattern string, coun
 	return keys, nil
 }
 
+// Close closes all database pools of all namespaces. The client must not be
+// used afterwards.
 func (c Client) Close() {
-	for name, client := range c.databases {
-		client.Close()
-		delete(c.databases, name)
+	for namespace, databases := range c.namespaces {
+		for name, client := range databases {
+			client.Close()
+			delete(databases, name)
+		}
+		delete(c.namespaces, namespace)
 	}
 }
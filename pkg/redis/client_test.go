@@ -2,12 +2,17 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 )
 
 var ctx = context.Background()
@@ -37,3 +42,483 @@ func TestHgetAll(t *testing.T) {
 		}
 	}
 }
+
+func TestClientReusesPooledConnections(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	t.Setenv("REDIS_ADDRESS", s.Addr())
+	t.Setenv("REDIS_POOL_SIZE", "2")
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	s.DB(2).HSet("hash1", "key1", "value1")
+
+	for i := 0; i < 20; i++ {
+		if _, err := redisClient.HgetAllFromDb(ctx, "COUNTERS_DB", "hash1"); err != nil {
+			t.Fatalf("HgetAllFromDb() error = %v", err)
+		}
+	}
+
+	stats, ok := findPoolStats(redisClient, DefaultNamespace, "COUNTERS_DB")
+	if !ok {
+		t.Fatalf("missing pool stats for COUNTERS_DB")
+	}
+	if stats.TotalConns != 1 {
+		t.Errorf("TotalConns = %d, want 1", stats.TotalConns)
+	}
+	if stats.Hits == 0 {
+		t.Errorf("Hits = 0, want connection reuse")
+	}
+
+	if _, err := redisClient.HgetAllFromDb(ctx, "UNKNOWN_DB", "hash1"); err == nil {
+		t.Errorf("expected error for unknown database")
+	}
+}
+
+func findPoolStats(redisClient Client, namespace, dbName string) (PoolStats, bool) {
+	for _, stats := range redisClient.PoolStats() {
+		if stats.Namespace == namespace && stats.Database == dbName {
+			return stats, true
+		}
+	}
+
+	return PoolStats{}, false
+}
+
+func TestNewClientRejectsInvalidPoolSize(t *testing.T) {
+	t.Setenv("REDIS_POOL_SIZE", "0")
+
+	if _, err := NewClient(); err == nil {
+		t.Errorf("expected error for zero pool size")
+	}
+}
+
+func writeDatabaseConfig(t *testing.T, dir, fileName string, s *miniredis.Miniredis, databases map[string]int) string {
+	t.Helper()
+
+	config := databaseConfigFile{
+		Instances: map[string]databaseInstance{
+			"redis": {Hostname: s.Host(), Port: mustAtoi(t, s.Port()), UnixSocketPath: "/var/run/redis/redis.sock"},
+		},
+		Databases: map[string]databaseDefinition{},
+	}
+	for name, id := range databases {
+		config.Databases[name] = databaseDefinition{Id: id, Instance: "redis"}
+	}
+
+	return writeJSONFile(t, filepath.Join(dir, fileName), config)
+}
+
+func writeJSONFile(t *testing.T, path string, value any) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+
+	return path
+}
+
+func mustAtoi(t *testing.T, value string) int {
+	t.Helper()
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+
+	return parsed
+}
+
+func TestClientUsesDatabaseConfigFile(t *testing.T) {
+	s := miniredis.RunT(t)
+	dir := t.TempDir()
+
+	configFile := writeDatabaseConfig(t, dir, "database_config.json", s, map[string]int{
+		"STATE_DB":        9,
+		"FLEX_COUNTER_DB": 5,
+	})
+
+	t.Setenv("REDIS_ADDRESS", "127.0.0.1:1")
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", configFile)
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	s.DB(9).HSet("hash1", "key1", "value1")
+
+	result, err := redisClient.HgetAllFromDb(ctx, "STATE_DB", "hash1")
+	if err != nil {
+		t.Fatalf("HgetAllFromDb() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, map[string]string{"key1": "value1"}) {
+		t.Errorf("STATE_DB data = %v, want data from db 9", result)
+	}
+
+	if _, err := redisClient.HgetAllFromDb(ctx, "FLEX_COUNTER_DB", "hash1"); err != nil {
+		t.Errorf("FLEX_COUNTER_DB lookup error = %v", err)
+	}
+	if _, err := redisClient.HgetAllFromDb(ctx, "APPL_DB", "hash1"); err == nil {
+		t.Errorf("expected error for database missing from config file")
+	}
+}
+
+func TestClientUsesDatabaseGlobalFileDefaultNamespace(t *testing.T) {
+	s := miniredis.RunT(t)
+	dir := t.TempDir()
+
+	writeDatabaseConfig(t, dir, "redis/sonic-db/database_config.json", s, map[string]int{"APPL_DB": 3})
+	globalFile := writeJSONFile(t, filepath.Join(dir, "sonic-db/database_global.json"), databaseGlobalFile{
+		Includes: []databaseInclude{{Include: "../redis/sonic-db/database_config.json"}},
+	})
+
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", filepath.Join(dir, "missing.json"))
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", globalFile)
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	s.DB(3).HSet("hash1", "key1", "value1")
+
+	result, err := redisClient.HgetAllFromDb(ctx, "APPL_DB", "hash1")
+	if err != nil {
+		t.Fatalf("HgetAllFromDb() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, map[string]string{"key1": "value1"}) {
+		t.Errorf("APPL_DB data = %v, want data from db 3", result)
+	}
+}
+
+func TestNewClientDatabaseConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("explicit missing file", func(t *testing.T) {
+		t.Setenv("REDIS_DATABASE_CONFIG_FILE", filepath.Join(dir, "missing.json"))
+		t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+		if _, err := NewClient(); err == nil {
+			t.Errorf("expected error for missing database config file")
+		}
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		configFile := writeJSONFile(t, filepath.Join(dir, "bad_instance.json"), databaseConfigFile{
+			Instances: map[string]databaseInstance{"redis": {Hostname: "127.0.0.1", Port: 6379}},
+			Databases: map[string]databaseDefinition{"APPL_DB": {Id: 0, Instance: "redis_chassis"}},
+		})
+		t.Setenv("REDIS_DATABASE_CONFIG_FILE", configFile)
+		t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+		if _, err := NewClient(); err == nil {
+			t.Errorf("expected error for unknown instance")
+		}
+	})
+
+	t.Run("unset files fall back to well-known databases", func(t *testing.T) {
+		t.Setenv("REDIS_DATABASE_CONFIG_FILE", "")
+		t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+		redisClient, err := NewClient()
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		defer redisClient.Close()
+
+		for _, dbName := range []string{"LOGLEVEL_DB", "FLEX_COUNTER_DB", "CHASSIS_APP_DB", "CHASSIS_STATE_DB"} {
+			if _, ok := findPoolStats(redisClient, DefaultNamespace, dbName); !ok {
+				t.Errorf("missing fallback database %s", dbName)
+			}
+		}
+	})
+}
+
+func TestClientNamespacesFromDatabaseGlobalFile(t *testing.T) {
+	host := miniredis.RunT(t)
+	asic0 := miniredis.RunT(t)
+	dir := t.TempDir()
+
+	writeDatabaseConfig(t, dir, "redis/sonic-db/database_config.json", host, map[string]int{"COUNTERS_DB": 2})
+	writeDatabaseConfig(t, dir, "redis0/sonic-db/database_config.json", asic0, map[string]int{"COUNTERS_DB": 2})
+	globalFile := writeJSONFile(t, filepath.Join(dir, "redis/sonic-db/database_global.json"), databaseGlobalFile{
+		Includes: []databaseInclude{
+			{Include: "database_config.json"},
+			{Namespace: "asic0", Include: "../../redis0/sonic-db/database_config.json"},
+		},
+	})
+
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", "")
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", globalFile)
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	if got, want := redisClient.Namespaces(), []string{DefaultNamespace, "asic0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Namespaces() = %q, want %q", got, want)
+	}
+
+	host.DB(2).HSet("hash1", "source", "host")
+	asic0.DB(2).HSet("hash1", "source", "asic0")
+
+	for namespace, want := range map[string]string{DefaultNamespace: "host", "asic0": "asic0"} {
+		result, err := redisClient.Namespace(namespace).HgetAllFromDb(ctx, "COUNTERS_DB", "hash1")
+		if err != nil {
+			t.Fatalf("namespace %q HgetAllFromDb() error = %v", namespace, err)
+		}
+		if result["source"] != want {
+			t.Errorf("namespace %q source = %q, want %q", namespace, result["source"], want)
+		}
+	}
+
+	if _, err := redisClient.Namespace("asic9").HgetAllFromDb(ctx, "COUNTERS_DB", "hash1"); err == nil {
+		t.Errorf("expected error for unknown namespace")
+	}
+	if _, ok := findPoolStats(redisClient, "asic0", "COUNTERS_DB"); !ok {
+		t.Errorf("missing pool stats for asic0 COUNTERS_DB")
+	}
+
+	t.Run("namespace cap", func(t *testing.T) {
+		t.Setenv("REDIS_MAX_NAMESPACES", "1")
+		t.Setenv("REDIS_DATABASE_GLOBAL_FILE", writeJSONFile(t, filepath.Join(dir, "redis/sonic-db/database_global_capped.json"), databaseGlobalFile{
+			Includes: []databaseInclude{
+				{Namespace: "asic0", Include: "../../redis0/sonic-db/database_config.json"},
+				{Namespace: "asic1", Include: "../../redis0/sonic-db/database_config.json"},
+			},
+		}))
+
+		if _, err := NewClient(); err == nil {
+			t.Errorf("expected error when namespaces exceed REDIS_MAX_NAMESPACES")
+		}
+	})
+
+	t.Run("invalid namespace", func(t *testing.T) {
+		t.Setenv("REDIS_DATABASE_GLOBAL_FILE", writeJSONFile(t, filepath.Join(dir, "redis/sonic-db/database_global_invalid.json"), databaseGlobalFile{
+			Includes: []databaseInclude{{Namespace: "asic 0", Include: "database_config.json"}},
+		}))
+
+		if _, err := NewClient(); err == nil {
+			t.Errorf("expected error for invalid namespace name")
+		}
+	})
+}
+
+func TestHgetAllManyFromDb(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	t.Setenv("REDIS_ADDRESS", s.Addr())
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", "")
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+	t.Setenv("REDIS_PIPELINE_BATCH_SIZE", "2")
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	keys := []string{"hash0", "missing", "hash2", "hash3", "hash4"}
+	for _, key := range keys {
+		if key != "missing" {
+			s.DB(1).HSet(key, "name", key)
+		}
+	}
+
+	// Dial the pooled connection first, so connection setup is not counted.
+	if _, err := redisClient.HgetAllFromDb(ctx, "ASIC_DB", "hash0"); err != nil {
+		t.Fatalf("HgetAllFromDb() error = %v", err)
+	}
+
+	counter := &roundTripCounter{}
+	redisClient.databases["ASIC_DB"].AddHook(counter)
+
+	result, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", keys)
+	if err != nil {
+		t.Fatalf("HgetAllManyFromDb() error = %v", err)
+	}
+
+	if len(result) != len(keys) {
+		t.Fatalf("len(result) = %d, want %d", len(result), len(keys))
+	}
+	for i, key := range keys {
+		want := map[string]string{"name": key}
+		if key == "missing" {
+			want = map[string]string{}
+		}
+		if !reflect.DeepEqual(result[i], want) {
+			t.Errorf("result[%d] = %v, want %v", i, result[i], want)
+		}
+	}
+
+	if counter.roundTrips != 3 {
+		t.Errorf("round trips = %d, want 3 batches of 2 keys", counter.roundTrips)
+	}
+
+	if result, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", nil); err != nil || len(result) != 0 {
+		t.Errorf("HgetAllManyFromDb(nil) = %v, %v, want empty result", result, err)
+	}
+	if _, err := redisClient.HgetAllManyFromDb(ctx, "UNKNOWN_DB", keys); err == nil {
+		t.Errorf("expected error for unknown database")
+	}
+}
+
+func TestNewClientRejectsInvalidPipelineBatchSize(t *testing.T) {
+	t.Setenv("REDIS_PIPELINE_BATCH_SIZE", "0")
+
+	if _, err := NewClient(); err == nil {
+		t.Errorf("expected error for zero pipeline batch size")
+	}
+}
+
+// roundTripCounter counts commands and pipelines sent to redis, each is one
+// network round trip.
+type roundTripCounter struct {
+	roundTrips int
+}
+
+func (counter *roundTripCounter) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (counter *roundTripCounter) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		counter.roundTrips++
+		return next(ctx, cmd)
+	}
+}
+
+func (counter *roundTripCounter) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		counter.roundTrips++
+		return next(ctx, cmds)
+	}
+}
+
+// BenchmarkHgetAll compares reading FDB sized key sets one key at a time and
+// through pipelined batches, reporting redis round trips per read.
+func BenchmarkHgetAll(b *testing.B) {
+	s := miniredis.RunT(b)
+
+	b.Setenv("REDIS_ADDRESS", s.Addr())
+	b.Setenv("REDIS_DATABASE_CONFIG_FILE", "")
+	b.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+	redisClient, err := NewClient()
+	if err != nil {
+		b.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	keys := make([]string, 2000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("ASIC_STATE:SAI_OBJECT_TYPE_FDB_ENTRY:%d", i)
+		s.DB(1).HSet(keys[i], "SAI_FDB_ENTRY_ATTR_TYPE", "SAI_FDB_ENTRY_TYPE_DYNAMIC")
+	}
+
+	counter := &roundTripCounter{}
+	redisClient.databases["ASIC_DB"].AddHook(counter)
+
+	b.Run("per-key", func(b *testing.B) {
+		counter.roundTrips = 0
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				if _, err := redisClient.HgetAllFromDb(ctx, "ASIC_DB", key); err != nil {
+					b.Fatalf("HgetAllFromDb() error = %v", err)
+				}
+			}
+		}
+		b.ReportMetric(float64(counter.roundTrips)/float64(b.N), "roundtrips/op")
+	})
+
+	b.Run("pipelined", func(b *testing.B) {
+		counter.roundTrips = 0
+		for i := 0; i < b.N; i++ {
+			if _, err := redisClient.HgetAllManyFromDb(ctx, "ASIC_DB", keys); err != nil {
+				b.Fatalf("HgetAllManyFromDb() error = %v", err)
+			}
+		}
+		b.ReportMetric(float64(counter.roundTrips)/float64(b.N), "roundtrips/op")
+	})
+}
+
+func TestPing(t *testing.T) {
+	s := miniredis.RunT(t)
+
+	t.Setenv("REDIS_ADDRESS", s.Addr())
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx, "APPL_DB", "COUNTERS_DB", "UNKNOWN_DB"); err != nil {
+		t.Errorf("Ping() error = %v, want reachable", err)
+	}
+
+	s.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	if err := redisClient.Ping(timeoutCtx, "APPL_DB"); err == nil {
+		t.Errorf("Ping() succeeded after redis was stopped")
+	}
+}
+
+func TestPingSkipsUnlistedInstances(t *testing.T) {
+	s := miniredis.RunT(t)
+	dir := t.TempDir()
+
+	// CHASSIS_APP_DB lives on an instance that is not running
+	config := databaseConfigFile{
+		Instances: map[string]databaseInstance{
+			"redis":         {Hostname: s.Host(), Port: mustAtoi(t, s.Port())},
+			"redis_chassis": {Hostname: "127.0.0.1", Port: 1},
+		},
+		Databases: map[string]databaseDefinition{
+			"APPL_DB":        {Id: 0, Instance: "redis"},
+			"COUNTERS_DB":    {Id: 2, Instance: "redis"},
+			"CHASSIS_APP_DB": {Id: 12, Instance: "redis_chassis"},
+		},
+	}
+	t.Setenv("REDIS_DATABASE_CONFIG_FILE", writeJSONFile(t, filepath.Join(dir, "database_config.json"), config))
+	t.Setenv("REDIS_DATABASE_GLOBAL_FILE", "")
+
+	redisClient, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx, "APPL_DB", "COUNTERS_DB"); err != nil {
+		t.Errorf("Ping() error = %v, want reachable", err)
+	}
+	if got := s.TotalConnectionCount(); got != 1 {
+		t.Errorf("Ping() opened %d connections, want 1 for the shared instance", got)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	if err := redisClient.Ping(timeoutCtx, "APPL_DB", "CHASSIS_APP_DB"); err == nil {
+		t.Errorf("Ping() of CHASSIS_APP_DB succeeded, want redis_chassis unreachable")
+	}
+}
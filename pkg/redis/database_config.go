@@ -0,0 +1,192 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// namespacePattern bounds namespace names used as metric label values.
+var namespacePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+const (
+	defaultDatabaseConfigFile = "/var/run/redis/sonic-db/database_config.json"
+	defaultDatabaseGlobalFile = "/var/run/redis/sonic-db/database_global.json"
+)
+
+// databaseEndpoint is where a single SONiC database lives.
+type databaseEndpoint struct {
+	network string
+	address string
+	dbId    int
+}
+
+// Layout of SONiC database_config.json
+type databaseConfigFile struct {
+	Instances map[string]databaseInstance   `json:"INSTANCES"`
+	Databases map[string]databaseDefinition `json:"DATABASES"`
+}
+
+type databaseInstance struct {
+	Hostname       string `json:"hostname"`
+	Port           int    `json:"port"`
+	UnixSocketPath string `json:"unix_socket_path"`
+}
+
+type databaseDefinition struct {
+	Id       int    `json:"id"`
+	Instance string `json:"instance"`
+}
+
+// Layout of SONiC database_global.json used on multi-ASIC platforms
+type databaseGlobalFile struct {
+	Includes []databaseInclude `json:"INCLUDES"`
+}
+
+type databaseInclude struct {
+	Namespace string `json:"namespace"`
+	Include   string `json:"include"`
+}
+
+// resolveNamespaces maps every namespace to its databases. On multi-ASIC
+// platforms namespaces come from database_global.json, the host namespace is
+// the include without a namespace. Otherwise SONiC database_config.json
+// describes the host namespace only. When neither default file exists the
+// hardcoded RedisDbId map on REDIS_ADDRESS is used instead. Explicitly
+// configured files must exist.
+func resolveNamespaces(cfg RedisConfig) (map[string]map[string]databaseEndpoint, error) {
+	namespaces := make(map[string]map[string]databaseEndpoint)
+	configFile := cfg.DatabaseConfigFile
+
+	if cfg.DatabaseGlobalFile != "" {
+		includes, err := readDatabaseGlobalFile(cfg.DatabaseGlobalFile)
+		switch {
+		case err == nil:
+			for _, include := range includes {
+				if include.Namespace == DefaultNamespace {
+					configFile = include.Include
+					continue
+				}
+
+				databases, err := readDatabaseConfigFile(include.Include, cfg.Network)
+				if err != nil {
+					return nil, fmt.Errorf("namespace %s: %w", include.Namespace, err)
+				}
+				namespaces[include.Namespace] = databases
+			}
+
+			if len(namespaces) > cfg.MaxNamespaces {
+				return nil, fmt.Errorf("database global file %s defines %d namespaces, more than REDIS_MAX_NAMESPACES %d", cfg.DatabaseGlobalFile, len(namespaces), cfg.MaxNamespaces)
+			}
+		case errors.Is(err, os.ErrNotExist) && cfg.DatabaseGlobalFile == defaultDatabaseGlobalFile:
+		default:
+			return nil, err
+		}
+	}
+
+	if configFile == "" {
+		namespaces[DefaultNamespace] = fallbackDatabases(cfg)
+		return namespaces, nil
+	}
+
+	databases, err := readDatabaseConfigFile(configFile, cfg.Network)
+	switch {
+	case err == nil:
+		namespaces[DefaultNamespace] = databases
+	case errors.Is(err, os.ErrNotExist) && configFile == defaultDatabaseConfigFile:
+		namespaces[DefaultNamespace] = fallbackDatabases(cfg)
+	default:
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// readDatabaseGlobalFile returns includes with paths resolved relative to the global file.
+func readDatabaseGlobalFile(path string) ([]databaseInclude, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database global file %s: %w", path, err)
+	}
+
+	var global databaseGlobalFile
+	if err := json.Unmarshal(data, &global); err != nil {
+		return nil, fmt.Errorf("failed to parse database global file %s: %w", path, err)
+	}
+
+	includes := make([]databaseInclude, 0, len(global.Includes))
+	for _, include := range global.Includes {
+		if include.Include == "" {
+			return nil, fmt.Errorf("database global file %s has an include without path", path)
+		}
+
+		if include.Namespace != DefaultNamespace && !namespacePattern.MatchString(include.Namespace) {
+			return nil, fmt.Errorf("database global file %s has invalid namespace %q", path, include.Namespace)
+		}
+
+		includePath := include.Include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(path), includePath)
+		}
+
+		includes = append(includes, databaseInclude{Namespace: include.Namespace, Include: includePath})
+	}
+
+	return includes, nil
+}
+
+func readDatabaseConfigFile(path, network string) (map[string]databaseEndpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database config file %s: %w", path, err)
+	}
+
+	var config databaseConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse database config file %s: %w", path, err)
+	}
+
+	if len(config.Databases) == 0 {
+		return nil, fmt.Errorf("database config file %s defines no databases", path)
+	}
+
+	databases := make(map[string]databaseEndpoint, len(config.Databases))
+	for name, database := range config.Databases {
+		instance, ok := config.Instances[database.Instance]
+		if !ok {
+			return nil, fmt.Errorf("database %s in %s references unknown instance %q", name, path, database.Instance)
+		}
+
+		endpoint := databaseEndpoint{dbId: database.Id}
+		if network == "unix" && instance.UnixSocketPath != "" {
+			endpoint.network = "unix"
+			endpoint.address = instance.UnixSocketPath
+		} else {
+			endpoint.network = "tcp"
+			endpoint.address = net.JoinHostPort(instance.Hostname, strconv.Itoa(instance.Port))
+		}
+
+		databases[name] = endpoint
+	}
+
+	return databases, nil
+}
+
+func fallbackDatabases(cfg RedisConfig) map[string]databaseEndpoint {
+	databases := make(map[string]databaseEndpoint, len(fallbackDatabaseNames))
+	for _, name := range fallbackDatabaseNames {
+		dbId, _ := RedisDbId(name)
+		databases[name] = databaseEndpoint{
+			network: cfg.Network,
+			address: cfg.Address,
+			dbId:    dbId,
+		}
+	}
+
+	return databases
+}